@@ -0,0 +1,92 @@
+package tradingview
+
+import (
+	"context"
+	"errors"
+)
+
+// SymbolError is the error sent by the server when a symbol can't be subscribed, for example because it doesn't exist.
+// It's not fatal: the symbol is removed from the session and the rest of the symbols keep streaming.
+type SymbolError struct {
+	Symbol  string
+	Message string
+}
+
+func (e *SymbolError) Error() string {
+	return "symbol " + e.Symbol + " rejected by the server: " + e.Message
+}
+
+// AddSymbolAndWait adds the symbol to the default quote session and waits until the server starts sending its data,
+// returning a *SymbolError if it's rejected
+func (s *Socket) AddSymbolAndWait(ctx context.Context, symbol string) (err error) {
+	return s.quoteSession.AddSymbolAndWait(ctx, symbol)
+}
+
+// AddSymbolAndWait adds the symbol and waits until the server starts sending its data,
+// returning a *SymbolError if it's rejected
+func (qs *QuoteSession) AddSymbolAndWait(ctx context.Context, symbol string) (err error) {
+	ack := make(chan error, 1)
+	qs.mutex.Lock()
+	qs.pendingAcks[symbol] = append(qs.pendingAcks[symbol], ack)
+	qs.mutex.Unlock()
+
+	err = qs.AddSymbol(symbol)
+	if err != nil {
+		qs.removePendingAck(symbol, ack)
+		return
+	}
+
+	select {
+	case err = <-ack:
+	case <-ctx.Done():
+		qs.removePendingAck(symbol, ack)
+		err = ctx.Err()
+	case <-qs.socket.ctx.Done():
+		qs.removePendingAck(symbol, ack)
+		err = errors.New("the socket was closed before the symbol was acknowledged")
+	}
+	return
+}
+
+// resolveAcks notifies everyone waiting for the symbol
+func (qs *QuoteSession) resolveAcks(symbol string, err error) {
+	qs.mutex.Lock()
+	acks := qs.pendingAcks[symbol]
+	delete(qs.pendingAcks, symbol)
+	qs.mutex.Unlock()
+
+	for _, ack := range acks {
+		ack <- err
+	}
+}
+
+func (qs *QuoteSession) removePendingAck(symbol string, ack chan error) {
+	qs.mutex.Lock()
+	defer qs.mutex.Unlock()
+
+	acks := qs.pendingAcks[symbol]
+	for i := range acks {
+		if acks[i] == ack {
+			qs.pendingAcks[symbol] = append(acks[:i], acks[i+1:]...)
+			break
+		}
+	}
+	if len(qs.pendingAcks[symbol]) == 0 {
+		delete(qs.pendingAcks, symbol)
+	}
+}
+
+// onSymbolError removes the rejected symbol from the session and reports it without closing the connection
+func (qs *QuoteSession) onSymbolError(symbolErr *SymbolError) {
+	qs.mutex.Lock()
+	delete(qs.symbols, symbolErr.Symbol)
+	delete(qs.snapshots, symbolErr.Symbol)
+	delete(qs.tradingSessions, symbolErr.Symbol)
+	delete(qs.lastVolumes, symbolErr.Symbol)
+	delete(qs.halted, symbolErr.Symbol)
+	qs.mutex.Unlock()
+	qs.onSubscriptionsChange()
+
+	qs.resolveAcks(symbolErr.Symbol, symbolErr)
+	qs.socket.OnErrorCallback(symbolErr, SymbolErrorContext)
+}
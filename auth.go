@@ -0,0 +1,79 @@
+package tradingview
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+)
+
+// TokenProvider returns a valid auth token. It's called when the server rejects the current one.
+type TokenProvider func(ctx context.Context) (string, error)
+
+// authTokenRejectedReasons are the texts found in the error messages sent when the auth token is no longer valid
+var authTokenRejectedReasons = []string{
+	"invalid_auth_token",
+	"auth_token_expired",
+	"wrong_auth_token",
+	"invalid_token",
+}
+
+// SetAuthToken sends the auth token through the live connection and keeps it for the reconnections.
+// Use an empty token to go back to the unauthorized one.
+func (s *Socket) SetAuthToken(token string) (err error) {
+	s.authMutex.Lock()
+	s.authToken = token
+	s.authMutex.Unlock()
+
+	return s.sendAuthToken()
+}
+
+func (s *Socket) getAuthToken() string {
+	s.authMutex.RLock()
+	defer s.authMutex.RUnlock()
+
+	if s.authToken == "" {
+		return UnauthorizedUserToken
+	}
+	return s.authToken
+}
+
+func (s *Socket) sendAuthToken() error {
+	return s.sendSocketMessage(getSocketMessage("set_auth_token", []string{s.getAuthToken()}))
+}
+
+func isAuthTokenRejectedMessage(message string, raw []byte) bool {
+	return isErrorMessageWithReason(message, raw, authTokenRejectedReasons)
+}
+
+func isErrorMessageWithReason(message string, raw []byte, reasons []string) bool {
+	if message != "critical_error" && message != "protocol_error" && message != "error" {
+		return false
+	}
+
+	for _, reason := range reasons {
+		if strings.Contains(string(raw), reason) {
+			return true
+		}
+	}
+	return false
+}
+
+// refreshAuthToken gets a new token from the provider, sends it and creates again all the quote sessions,
+// so the stream continues without reconnecting. If it fails, the connection is closed as with any other error.
+func (s *Socket) refreshAuthToken() {
+	if !atomic.CompareAndSwapInt32(&s.refreshingToken, 0, 1) {
+		return
+	}
+	defer atomic.StoreInt32(&s.refreshingToken, 0)
+
+	token, err := s.tokenProvider(s.ctx)
+	if err == nil {
+		err = s.SetAuthToken(token)
+	}
+	if err == nil {
+		err = s.recreateQuoteSessions(s.getQuoteSessions())
+	}
+	if err != nil && s.ctx.Err() == nil {
+		s.onError(err, RefreshAuthTokenErrorContext)
+	}
+}
@@ -0,0 +1,182 @@
+package tradingview
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// BarGap - Candles missing from a series, between two candles received
+type BarGap struct {
+	// From and To are the times of the first and the last candle missing
+	From    time.Time
+	To      time.Time
+	Missing int
+	// Confirmed is true when the range was requested again and the server doesn't have the candles either,
+	// so there were no trades, instead of an outage of the feed
+	Confirmed bool
+}
+
+// DetectGaps returns the candles missing between the candles of an intraday timeframe, oldest first.
+// With the trading hours of the symbol (ChartSession.TradingHours) the times when the market is closed are not missing,
+// without them every time is. The timeframes of a day or longer are not checked.
+func DetectGaps(candles []*Candle, timeframe Timeframe, hours *TradingHours) (gaps []*BarGap) {
+	step := timeframe.Duration()
+	if step <= 0 || !timeframe.IsIntraday() {
+		return
+	}
+
+	for i := 1; i < len(candles); i++ {
+		var gap *BarGap
+		for t := candles[i-1].Time.Add(step); t.Before(candles[i].Time); t = t.Add(step) {
+			if hours != nil && !hours.IsOpen(t) {
+				continue
+			}
+			if gap == nil {
+				gap = &BarGap{From: t}
+			}
+			gap.To = t
+			gap.Missing++
+		}
+		if gap != nil {
+			gaps = append(gaps, gap)
+		}
+	}
+	return
+}
+
+// WithOnHistoryGaps calls the callback with the candles missing in the history returned by GetHistory and GetHistoryRange,
+// when there are any
+func WithOnHistoryGaps(onGaps OnHistoryGapsCallback) ChartOption {
+	return func(cs *ChartSession) error {
+		cs.onGaps = onGaps
+		return nil
+	}
+}
+
+// WithGapFill requests again the ranges of the candles missing in the history returned by GetHistory and GetHistoryRange,
+// adding the ones the server sends. The neighbouring gaps are requested together, in 10 ranges at most starting
+// from the newest ones, waiting the request interval of the history between them (see WithHistoryRequestInterval).
+// The gaps that remain in those ranges are reported to WithOnHistoryGaps as confirmed.
+func WithGapFill() ChartOption {
+	return func(cs *ChartSession) error {
+		cs.fillGaps = true
+		return nil
+	}
+}
+
+// maxGapFillRequests is the most ranges WithGapFill requests for a history, the gaps beyond them are not filled
+const maxGapFillRequests = 10
+
+// gapFillRange - Range of candles requested again by WithGapFill, with the neighbouring gaps merged
+type gapFillRange struct {
+	from time.Time
+	to   time.Time
+}
+
+// handleHistoryGaps detects the gaps of the history, fills them when requested and reports the ones that remain
+func (s *Socket) handleHistoryGaps(ctx context.Context, cs *ChartSession, candles []*Candle, options []ChartOption) []*Candle {
+	if cs.onGaps == nil && !cs.fillGaps {
+		return candles
+	}
+
+	gaps := DetectGaps(candles, cs.timeframe, cs.TradingHours())
+	if cs.fillGaps && len(gaps) > 0 {
+		ranges := getGapFillRanges(gaps, cs.timeframe)
+		candles = s.fillHistoryGaps(ctx, cs, candles, ranges, options)
+		gaps = DetectGaps(candles, cs.timeframe, cs.TradingHours())
+		for _, gap := range gaps {
+			gap.Confirmed = isGapRequested(gap, ranges)
+		}
+	}
+
+	if cs.onGaps != nil && len(gaps) > 0 {
+		cs.onGaps(cs.symbol, gaps)
+	}
+	return candles
+}
+
+// getGapFillRanges merges the neighbouring gaps in ranges of up to maxHistoryBars candles, the newest first,
+// and returns maxGapFillRequests of them at most
+func getGapFillRanges(gaps []*BarGap, timeframe Timeframe) (ranges []*gapFillRange) {
+	maxSpan := time.Duration(maxHistoryBars) * timeframe.Duration()
+	for i := len(gaps) - 1; i >= 0; i-- {
+		gap := gaps[i]
+		if len(ranges) > 0 && ranges[len(ranges)-1].to.Sub(gap.From) < maxSpan {
+			ranges[len(ranges)-1].from = gap.From
+			continue
+		}
+		if len(ranges) == maxGapFillRequests {
+			return
+		}
+		ranges = append(ranges, &gapFillRange{from: gap.From, to: gap.To})
+	}
+	return
+}
+
+func isGapRequested(gap *BarGap, ranges []*gapFillRange) bool {
+	for _, r := range ranges {
+		if !gap.From.Before(r.from) && !gap.To.After(r.to) {
+			return true
+		}
+	}
+	return false
+}
+
+// fillHistoryGaps requests the ranges, with the same options as the history and waiting its request interval
+// between them, and merges the candles received
+func (s *Socket) fillHistoryGaps(
+	ctx context.Context,
+	cs *ChartSession,
+	candles []*Candle,
+	ranges []*gapFillRange,
+	options []ChartOption,
+) []*Candle {
+	interval := cs.requestInterval
+	if interval == 0 {
+		interval = DefaultHistoryRequestInterval
+	}
+
+	merged := map[int64]*Candle{}
+	for _, candle := range candles {
+		merged[candle.Time.Unix()] = candle
+	}
+
+	for i, r := range ranges {
+		if i > 0 {
+			select {
+			case <-time.After(interval):
+			case <-ctx.Done():
+				return candles
+			}
+		}
+
+		gapSession, err := s.newHistoryChartSession(cs.symbol, cs.timeframe, maxHistoryBars, options...)
+		if err != nil {
+			return candles
+		}
+		gapSession.onGaps = nil
+		gapSession.fillGaps = false
+		gapSession.barsRange = "r," + strconv.FormatInt(r.from.Unix(), 10) + ":" + strconv.FormatInt(r.to.Unix(), 10)
+
+		filled, err := s.getHistory(ctx, gapSession, 0, time.Time{})
+		if err != nil {
+			s.OnErrorCallback(err, ChartSessionErrorContext)
+			continue
+		}
+		for _, candle := range filled {
+			// The candles already received are kept, only the missing ones are added
+			if _, ok := merged[candle.Time.Unix()]; !ok && !candle.Time.Before(r.from) && !candle.Time.After(r.to) {
+				merged[candle.Time.Unix()] = candle
+			}
+		}
+	}
+
+	candles = make([]*Candle, 0, len(merged))
+	for _, candle := range merged {
+		candles = append(candles, candle)
+	}
+	sort.Slice(candles, func(i, j int) bool { return candles[i].Time.Before(candles[j].Time) })
+	return candles
+}
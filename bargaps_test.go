@@ -0,0 +1,170 @@
+package tradingview
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDetectGaps(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	chicago, err := time.LoadLocation("America/Chicago")
+	if err != nil {
+		t.Fatal(err)
+	}
+	stocks, err := ParseTradingHours("0930-1600:23456", newYork)
+	if err != nil {
+		t.Fatal(err)
+	}
+	futures, err := ParseTradingHours("1700-1600:23456", chicago)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	candlesAt := func(times ...time.Time) (candles []*Candle) {
+		for _, t := range times {
+			candles = append(candles, &Candle{Time: t})
+		}
+		return
+	}
+	// 2024-03-08 is a Friday and 2024-03-11 a Monday, in the week the clocks changed
+	friday := func(hour, minute int, location *time.Location) time.Time {
+		return time.Date(2024, 3, 8, hour, minute, 0, 0, location)
+	}
+	monday := func(hour, minute int, location *time.Location) time.Time {
+		return time.Date(2024, 3, 11, hour, minute, 0, 0, location)
+	}
+
+	tests := []struct {
+		name      string
+		candles   []*Candle
+		timeframe Timeframe
+		hours     *TradingHours
+		expected  []*BarGap
+	}{
+		{
+			name:      "no gaps",
+			candles:   candlesAt(friday(9, 30, newYork), friday(10, 30, newYork), friday(11, 30, newYork)),
+			timeframe: Timeframe1Hour,
+			hours:     stocks,
+		},
+		{
+			name:      "gap during the session",
+			candles:   candlesAt(friday(9, 30, newYork), friday(12, 30, newYork)),
+			timeframe: Timeframe1Hour,
+			hours:     stocks,
+			expected:  []*BarGap{{From: friday(10, 30, newYork), To: friday(11, 30, newYork), Missing: 2}},
+		},
+		{
+			name:      "weekend",
+			candles:   candlesAt(friday(15, 30, newYork), monday(9, 30, newYork)),
+			timeframe: Timeframe1Hour,
+			hours:     stocks,
+		},
+		{
+			name:      "weekend without trading hours",
+			candles:   candlesAt(friday(15, 0, time.UTC), monday(9, 0, time.UTC)),
+			timeframe: Timeframe1Hour,
+			expected:  []*BarGap{{From: friday(16, 0, time.UTC), To: monday(8, 0, time.UTC), Missing: 65}},
+		},
+		{
+			name:      "overnight session",
+			candles:   candlesAt(monday(15, 0, chicago), monday(18, 0, chicago)),
+			timeframe: Timeframe1Hour,
+			hours:     futures,
+			expected:  []*BarGap{{From: monday(17, 0, chicago), To: monday(17, 0, chicago), Missing: 1}},
+		},
+		{
+			name:      "overnight session after the weekend",
+			candles:   candlesAt(friday(15, 0, chicago), time.Date(2024, 3, 10, 17, 0, 0, 0, chicago)),
+			timeframe: Timeframe1Hour,
+			hours:     futures,
+		},
+		{
+			name:      "daily timeframe",
+			candles:   candlesAt(friday(0, 0, time.UTC), monday(0, 0, time.UTC).AddDate(0, 0, 7)),
+			timeframe: Timeframe1Day,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gaps := DetectGaps(test.candles, test.timeframe, test.hours)
+			if len(gaps) != len(test.expected) {
+				t.Fatalf("expected %d gaps, got %d", len(test.expected), len(gaps))
+			}
+			for i, gap := range gaps {
+				expected := test.expected[i]
+				if !gap.From.Equal(expected.From) || !gap.To.Equal(expected.To) || gap.Missing != expected.Missing {
+					t.Fatalf("expected the gap %v - %v of %d candles, got %v - %v of %d",
+						expected.From, expected.To, expected.Missing, gap.From, gap.To, gap.Missing)
+				}
+			}
+		})
+	}
+}
+
+func TestGetGapFillRanges(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	gapAt := func(days int) *BarGap {
+		from := start.AddDate(0, 0, days)
+		return &BarGap{From: from, To: from.Add(10 * time.Minute), Missing: 11}
+	}
+	// The gaps of a month apart are further than maxHistoryBars minutes, so they're never merged
+	var distant []*BarGap
+	for i := 0; i < 12; i++ {
+		distant = append(distant, gapAt(i*30))
+	}
+
+	tests := []struct {
+		name     string
+		gaps     []*BarGap
+		expected []*gapFillRange
+	}{
+		{
+			name: "no gaps",
+		},
+		{
+			name: "neighbouring gaps",
+			gaps: []*BarGap{gapAt(0), gapAt(1), gapAt(2)},
+			expected: []*gapFillRange{
+				{from: gapAt(0).From, to: gapAt(2).To},
+			},
+		},
+		{
+			name: "distant gaps",
+			gaps: []*BarGap{gapAt(0), gapAt(30)},
+			expected: []*gapFillRange{
+				{from: gapAt(30).From, to: gapAt(30).To},
+				{from: gapAt(0).From, to: gapAt(0).To},
+			},
+		},
+		{
+			name: "more gaps than requests",
+			gaps: distant,
+			expected: func() (ranges []*gapFillRange) {
+				for i := len(distant) - 1; i >= len(distant)-maxGapFillRequests; i-- {
+					ranges = append(ranges, &gapFillRange{from: distant[i].From, to: distant[i].To})
+				}
+				return
+			}(),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ranges := getGapFillRanges(test.gaps, Timeframe1Minute)
+			if len(ranges) != len(test.expected) {
+				t.Fatalf("expected %d ranges, got %d", len(test.expected), len(ranges))
+			}
+			for i, r := range ranges {
+				expected := test.expected[i]
+				if !r.from.Equal(expected.from) || !r.to.Equal(expected.to) {
+					t.Fatalf("expected the range %v - %v, got %v - %v", expected.from, expected.to, r.from, r.to)
+				}
+			}
+		})
+	}
+}
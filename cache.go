@@ -0,0 +1,212 @@
+package tradingview
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// HistoryCache keeps the candles returned by GetHistory and GetHistoryRange, so the same requests don't reach the server again
+type HistoryCache interface {
+	// Get returns nil, without error, when the key is not cached or it has expired
+	Get(key string) ([]*Candle, error)
+	Set(key string, candles []*Candle) error
+	Delete(key string) error
+	// TTL returns how long the candles are kept, 0 when they never expire.
+	// The requests of the last candles are only cached with a TTL, as they change with every new bar.
+	TTL() time.Duration
+}
+
+// FileHistoryCache - HistoryCache that keeps every request in a JSON file of a directory
+type FileHistoryCache struct {
+	dir   string
+	ttl   time.Duration
+	mutex sync.Mutex
+}
+
+type fileHistoryCacheEntry struct {
+	SavedAt time.Time `json:"saved_at"`
+	Candles []*Candle `json:"candles"`
+}
+
+// NewFileHistoryCache creates a cache in the directory, where the candles expire after the ttl, or never when it's 0
+func NewFileHistoryCache(dir string, ttl time.Duration) (cache *FileHistoryCache, err error) {
+	if dir == "" {
+		return nil, errors.New("the directory of the cache can't be empty")
+	}
+	if ttl < 0 {
+		return nil, errors.New("the ttl of the cache can't be negative")
+	}
+
+	err = os.MkdirAll(dir, 0755)
+	if err != nil {
+		return
+	}
+
+	cache = &FileHistoryCache{dir: dir, ttl: ttl}
+	return
+}
+
+// Get ...
+func (fc *FileHistoryCache) Get(key string) (candles []*Candle, err error) {
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+
+	content, err := os.ReadFile(fc.getPath(key))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return
+	}
+
+	var entry fileHistoryCacheEntry
+	err = json.Unmarshal(content, &entry)
+	if err != nil {
+		return
+	}
+	if fc.ttl > 0 && time.Since(entry.SavedAt) > fc.ttl {
+		return nil, nil
+	}
+	return entry.Candles, nil
+}
+
+// Set ...
+func (fc *FileHistoryCache) Set(key string, candles []*Candle) (err error) {
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+
+	content, err := json.Marshal(&fileHistoryCacheEntry{SavedAt: time.Now(), Candles: candles})
+	if err != nil {
+		return
+	}
+	return writeFileAtomic(fc.getPath(key), content)
+}
+
+// TTL ...
+func (fc *FileHistoryCache) TTL() time.Duration {
+	return fc.ttl
+}
+
+// Delete ...
+func (fc *FileHistoryCache) Delete(key string) (err error) {
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+
+	err = os.Remove(fc.getPath(key))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return
+}
+
+// Clear deletes all the cached candles
+func (fc *FileHistoryCache) Clear() (err error) {
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+
+	paths, err := filepath.Glob(filepath.Join(fc.dir, "*.json"))
+	if err != nil {
+		return
+	}
+	for _, path := range paths {
+		err = os.Remove(path)
+		if err != nil && !os.IsNotExist(err) {
+			return
+		}
+	}
+	return nil
+}
+
+// getPath hashes the key, which has characters that can't be in a file name
+func (fc *FileHistoryCache) getPath(key string) string {
+	hash := sha1.Sum([]byte(key))
+	return filepath.Join(fc.dir, hex.EncodeToString(hash[:])+".json")
+}
+
+// WithHistoryCacheRefresh skips the candles cached for the request, loading them from the server and caching them again
+func WithHistoryCacheRefresh() ChartOption {
+	return func(cs *ChartSession) error {
+		cs.refreshCache = true
+		return nil
+	}
+}
+
+// getHistoryCacheKey returns the key of the candles of the chart session in the cache: the symbol with its options,
+// the timeframe, the range, the number of candles and whether the gaps are filled
+func getHistoryCacheKey(cs *ChartSession, barCount int) (key string, err error) {
+	descriptor, err := json.Marshal(cs.getSymbolDescriptor())
+	if err != nil {
+		return
+	}
+	return string(descriptor) + "|" + string(cs.timeframe) + "|" + cs.barsRange + "|" + strconv.Itoa(barCount) +
+		"|" + strconv.FormatBool(cs.fillGaps), nil
+}
+
+// getCachedHistory returns the candles of the cache, or loads them and caches them when they're not there.
+// The ranges, which end at to, are only cached once they've ended, and the requests of the last candles, with a zero to,
+// only when the cache has a TTL. The candle that is still forming is never cached.
+func (s *Socket) getCachedHistory(
+	cs *ChartSession,
+	barCount int,
+	to time.Time,
+	load func() ([]*Candle, error),
+) (candles []*Candle, err error) {
+	if !s.isHistoryCacheable(to) {
+		return load()
+	}
+
+	key, err := getHistoryCacheKey(cs, barCount)
+	if err != nil {
+		return
+	}
+
+	if !cs.refreshCache {
+		candles, err = s.historyCache.Get(key)
+		if err != nil {
+			s.OnErrorCallback(err, HistoryCacheErrorContext)
+		}
+		if candles != nil {
+			return candles, nil
+		}
+	}
+
+	candles, err = load()
+	if err != nil {
+		return
+	}
+
+	if cacheErr := s.historyCache.Set(key, getClosedCandles(candles, cs.timeframe)); cacheErr != nil {
+		s.OnErrorCallback(cacheErr, HistoryCacheErrorContext)
+	}
+	return
+}
+
+func (s *Socket) isHistoryCacheable(to time.Time) bool {
+	if s.historyCache == nil {
+		return false
+	}
+	if to.IsZero() {
+		return s.historyCache.TTL() > 0
+	}
+	return to.Before(time.Now())
+}
+
+// getClosedCandles returns the candles without the last one when it's still forming
+func getClosedCandles(candles []*Candle, timeframe Timeframe) []*Candle {
+	if len(candles) == 0 {
+		return candles
+	}
+
+	last := candles[len(candles)-1]
+	if last.Time.Add(timeframe.Duration()).After(time.Now()) {
+		return candles[:len(candles)-1]
+	}
+	return candles
+}
@@ -0,0 +1,74 @@
+package tradingview
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrSymbolNotFound is wrapped by the *ChartError of the symbols the server can't resolve
+var ErrSymbolNotFound = errors.New("symbol not found")
+
+// ErrResolutionNotSupported is wrapped by the *ChartError of the series in a timeframe the symbol doesn't have
+var ErrResolutionNotSupported = errors.New("resolution not supported")
+
+// ErrStudyAccessDenied is wrapped by the *ChartError of the studies the account can't use, as the invite-only scripts
+var ErrStudyAccessDenied = errors.New("access to the study denied")
+
+// ChartError is the error sent by the server for a series, a symbol or a study of a chart session.
+// Use errors.Is with ErrSymbolNotFound, ErrResolutionNotSupported or ErrStudyAccessDenied to know the cause.
+type ChartError struct {
+	// Message is the message of the server, as series_error or study_error
+	Message string
+	Symbol  string
+	Reason  string
+	err     error
+}
+
+func (e *ChartError) Error() string {
+	return e.Message + " for " + e.Symbol + ": " + e.Reason
+}
+
+// Unwrap returns the cause of the error, if it's known
+func (e *ChartError) Unwrap() error {
+	return e.err
+}
+
+// newChartError decodes the error of the message, {"m": "series_error", "p": [sessionID, seriesID, ..., reason]}
+func newChartError(message string, symbol string, payload []interface{}) *ChartError {
+	e := &ChartError{Message: message, Symbol: symbol, Reason: getChartErrorReason(payload)}
+
+	reason := strings.ToLower(e.Reason)
+	switch {
+	case message == "symbol_error" || strings.Contains(reason, "invalid symbol") || strings.Contains(reason, "unknown symbol"):
+		e.err = ErrSymbolNotFound
+	case strings.Contains(reason, "resolution"):
+		e.err = ErrResolutionNotSupported
+	case message == "study_error" && containsAny(reason, "auth", "access", "permission", "invite", "not allowed"):
+		e.err = ErrStudyAccessDenied
+	}
+	return e
+}
+
+// getChartErrorReason returns the last text of the payload, or the error of the last object
+func getChartErrorReason(payload []interface{}) string {
+	for i := len(payload) - 1; i > 0; i-- {
+		switch value := payload[i].(type) {
+		case string:
+			return value
+		case map[string]interface{}:
+			if reason, ok := value["error"].(string); ok {
+				return reason
+			}
+		}
+	}
+	return "unknown error"
+}
+
+func containsAny(s string, substrings ...string) bool {
+	for _, substring := range substrings {
+		if strings.Contains(s, substring) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,107 @@
+package tradingview
+
+import "errors"
+
+// ChartOption ...
+type ChartOption func(cs *ChartSession) error
+
+// ChartType - Type of the bars computed by the server, as the chart types of the website
+type ChartType string
+
+// ChartTypeCandles are the regular candles, the default
+const ChartTypeCandles ChartType = ""
+
+// ChartTypeHeikinAshi ...
+const ChartTypeHeikinAshi ChartType = "BarSetHeikenAshi@tv-basicstudies-60!"
+
+// ChartTypeRenko ...
+const ChartTypeRenko ChartType = "BarSetRenko@tv-prostudies-40!"
+
+// ChartTypeRange ...
+const ChartTypeRange ChartType = "BarSetRange@tv-basicstudies-72!"
+
+// ChartTypeKagi ...
+const ChartTypeKagi ChartType = "BarSetKagi@tv-prostudies-34!"
+
+// ChartTypeLineBreak ...
+const ChartTypeLineBreak ChartType = "BarSetPriceBreak@tv-prostudies-34!"
+
+// ChartTypePointAndFigure ...
+const ChartTypePointAndFigure ChartType = "BarSetPnF@tv-prostudies-34!"
+
+// WithChartType makes the server compute the bars of the given type instead of the regular candles.
+// The inputs are the settings of the type, as {"boxSize": 3, "style": "ATR", "atrLength": 14} for Renko
+// or {"range": 10} for Range, and can be nil for the defaults.
+func WithChartType(chartType ChartType, inputs map[string]interface{}) ChartOption {
+	return func(cs *ChartSession) error {
+		if chartType == ChartTypeCandles && len(inputs) > 0 {
+			return errors.New("the regular candles don't have inputs")
+		}
+		if inputs == nil {
+			inputs = map[string]interface{}{}
+		}
+
+		cs.chartType = chartType
+		cs.chartInputs = inputs
+		return nil
+	}
+}
+
+// Adjustment - Adjustment of the historical prices for the corporate actions
+type Adjustment string
+
+// AdjustmentSplits adjusts the prices before the splits, the default
+const AdjustmentSplits Adjustment = "splits"
+
+// AdjustmentDividends adjusts the prices for the splits and the dividends
+const AdjustmentDividends Adjustment = "dividends"
+
+// AdjustmentNone leaves the prices as they were traded
+const AdjustmentNone Adjustment = "none"
+
+// WithAdjustment sets the adjustment of the historical prices: AdjustmentSplits, AdjustmentDividends or AdjustmentNone
+func WithAdjustment(adjustment Adjustment) ChartOption {
+	return func(cs *ChartSession) error {
+		if adjustment != AdjustmentSplits && adjustment != AdjustmentDividends && adjustment != AdjustmentNone {
+			return errors.New("invalid adjustment " + string(adjustment))
+		}
+
+		cs.adjustment = adjustment
+		return nil
+	}
+}
+
+// WithBackAdjustment removes the gaps between the contracts of a continuous futures symbol (ES1!, NQ2!...),
+// adjusting the prices before every rollover
+func WithBackAdjustment() ChartOption {
+	return func(cs *ChartSession) error {
+		if !IsContinuousFutures(cs.symbol) {
+			return errors.New("the back-adjustment is only for the continuous futures, as CME_MINI:ES1!")
+		}
+
+		cs.backAdjusted = true
+		return nil
+	}
+}
+
+// WithExtendedHours includes the bars of the pre-market and the after-hours, as the extended hours toggle of the website.
+// By default the series only has the bars of the regular session.
+func WithExtendedHours() ChartOption {
+	return func(cs *ChartSession) error {
+		cs.extendedHours = true
+		return nil
+	}
+}
+
+// WithOnSymbolResolved calls the callback with the metadata of the symbol when the server resolves it,
+// before sending the history of the series
+func WithOnSymbolResolved(onResolved OnSymbolInfoCallback) ChartOption {
+	return func(cs *ChartSession) error {
+		if onResolved == nil {
+			return errors.New("the symbol resolved callback can't be nil")
+		}
+
+		cs.onResolved = onResolved
+		return nil
+	}
+}
@@ -0,0 +1,635 @@
+package tradingview
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+// errMessageHandled is returned by parseJSON for the messages that have been handled by a chart session
+var errMessageHandled = errors.New("message handled by a chart session")
+
+// Candle - OHLCV bar of a chart series
+type Candle struct {
+	// Time is the start of the candle in UTC, and ExchangeTime the same instant in the time zone of the exchange
+	Time         time.Time
+	ExchangeTime time.Time
+	Open         float64
+	High         float64
+	Low          float64
+	Close        float64
+	Volume       float64
+	// Closed is true when the candle is final. The updates of the forming candle have false,
+	// and it's delivered once more with true, as it ended, before the first update of the next one.
+	Closed bool
+}
+
+// ChartSession - Chart session streaming the candles of a symbol and timeframe, on the same connection as the quote sessions
+type ChartSession struct {
+	socket   *Socket
+	onCandle OnCandleCallback
+
+	id        string
+	symbol    string
+	timeframe Timeframe
+	barCount  int
+	// barsRange limits the series to a time range, as "r,from:to" with unix times
+	barsRange string
+	mutex     sync.RWMutex
+
+	chartType     ChartType
+	chartInputs   map[string]interface{}
+	extendedHours bool
+	adjustment    Adjustment
+	backAdjusted  bool
+	// frontContract is the contract the continuous futures series follows, from the resolved symbol
+	frontContract string
+	location      *time.Location
+	tradingHours  *TradingHours
+	symbolInfo    *SymbolInfo
+	onResolved    OnSymbolInfoCallback
+
+	// loaded is closed when the server has sent the history of the series, and failed receives its errors
+	loaded     chan struct{}
+	loadedOnce sync.Once
+	failed     chan error
+	// completed receives every time the server has sent the bars requested, the first ones or more with request_more_data
+	completed chan struct{}
+	// onProgress and requestInterval are the settings of the pagination of GetHistory
+	onProgress      OnHistoryProgressCallback
+	requestInterval time.Duration
+	refreshCache    bool
+	onGaps          OnHistoryGapsCallback
+	fillGaps        bool
+
+	// forming is the last candle received, the one still open
+	forming *Candle
+
+	studies     map[string]*Study
+	lastStudyID int
+
+	// parent is the chart session of the series added with AddSeries, which share its session id
+	parent *ChartSession
+	// index is the number of the series in the chart session, 1 for the first one
+	index           int
+	series          map[string]*ChartSession
+	lastSeriesIndex int
+
+	// replay is the replay session that drives the series, if any
+	replay *ReplaySession
+	// onLoaded is called once when the history has been received, before the live updates
+	onLoaded func()
+	// collected returns the candles received, only for the sessions of GetHistory
+	collected func() []*Candle
+}
+
+// NewChartSession creates a chart session that loads the last barCount candles of the symbol in the given timeframe
+// (Timeframe1Minute, Timeframe1Hour, Timeframe1Day...), and then keeps streaming the updates of the last candle and the new ones.
+// Every candle received is delivered to onCandle, the history first and then the live updates.
+// The options (WithChartType...) change how the series is computed.
+func (s *Socket) NewChartSession(
+	symbol string,
+	timeframe Timeframe,
+	barCount int,
+	onCandle OnCandleCallback,
+	options ...ChartOption,
+) (session *ChartSession, err error) {
+	err = validateChartSeries(symbol, timeframe, barCount, onCandle)
+	if err != nil {
+		return
+	}
+
+	cs, err := s.newChartSession(symbol, timeframe, barCount, onCandle, options...)
+	if err != nil {
+		return
+	}
+	err = s.createChartSession(cs)
+	if err != nil {
+		return
+	}
+
+	session = cs
+	return
+}
+
+func (s *Socket) newChartSession(
+	symbol string,
+	timeframe Timeframe,
+	barCount int,
+	onCandle OnCandleCallback,
+	options ...ChartOption,
+) (cs *ChartSession, err error) {
+	cs = &ChartSession{
+		socket:     s,
+		onCandle:   onCandle,
+		symbol:     symbol,
+		timeframe:  timeframe,
+		barCount:   barCount,
+		adjustment: AdjustmentSplits,
+		index:      1,
+		loaded:     make(chan struct{}),
+		failed:     make(chan error, 1),
+		completed:  make(chan struct{}, 1),
+	}
+	for _, option := range options {
+		err = option(cs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	cs.generateID()
+	return
+}
+
+func (s *Socket) createChartSession(cs *ChartSession) (err error) {
+	s.registerChartSession(cs)
+
+	err = cs.sendCreateMessages()
+	if err != nil {
+		s.unregisterChartSession(cs)
+	}
+	return
+}
+
+// AddSeries adds another symbol or timeframe to the chart session, with its own callback and options, so many series
+// can be streamed with a single session. The series returned works as a chart session, and closing it only removes the series.
+func (cs *ChartSession) AddSeries(
+	symbol string,
+	timeframe Timeframe,
+	barCount int,
+	onCandle OnCandleCallback,
+	options ...ChartOption,
+) (series *ChartSession, err error) {
+	err = validateChartSeries(symbol, timeframe, barCount, onCandle)
+	if err != nil {
+		return
+	}
+
+	child, err := cs.socket.newChartSession(symbol, timeframe, barCount, onCandle, options...)
+	if err != nil {
+		return
+	}
+
+	root := cs.root()
+	root.mutex.Lock()
+	root.lastSeriesIndex++
+	child.parent = root
+	child.index = root.lastSeriesIndex
+	if root.series == nil {
+		root.series = map[string]*ChartSession{}
+	}
+	root.series[child.seriesID()] = child
+	root.mutex.Unlock()
+
+	err = child.sendSeriesMessages(root.SessionID())
+	if err != nil {
+		root.removeSeries(child)
+		return
+	}
+
+	series = child
+	return
+}
+
+func validateChartSeries(symbol string, timeframe Timeframe, barCount int, onCandle OnCandleCallback) (err error) {
+	if symbol == "" {
+		return errors.New("the symbol of the chart session can't be empty")
+	}
+	err = timeframe.Validate()
+	if err != nil {
+		return
+	}
+	if barCount <= 0 {
+		return errors.New("the number of bars of the chart session must be positive")
+	}
+	if onCandle == nil {
+		return errors.New("the candle callback of the chart session can't be nil")
+	}
+	return
+}
+
+// SessionID ...
+func (cs *ChartSession) SessionID() string {
+	if cs.parent != nil {
+		return cs.parent.SessionID()
+	}
+
+	cs.mutex.RLock()
+	defer cs.mutex.RUnlock()
+
+	return cs.id
+}
+
+// Close deletes the chart session from the server. The connection and the rest of the sessions remain open.
+// For the series added with AddSeries, only the series is removed.
+func (cs *ChartSession) Close() (err error) {
+	if cs.parent != nil {
+		cs.parent.removeSeries(cs)
+		return cs.socket.sendSocketMessage(
+			getSocketMessage("remove_series", []string{cs.SessionID(), cs.seriesID()}),
+		)
+	}
+
+	cs.socket.unregisterChartSession(cs)
+
+	err = cs.socket.sendSocketMessage(
+		getSocketMessage("chart_delete_session", []string{cs.SessionID()}),
+	)
+	return
+}
+
+// FrontContract returns the contract followed by the series of a continuous futures symbol (ES1!...), once it's resolved
+func (cs *ChartSession) FrontContract() string {
+	cs.mutex.RLock()
+	defer cs.mutex.RUnlock()
+
+	return cs.frontContract
+}
+
+func (cs *ChartSession) root() *ChartSession {
+	if cs.parent != nil {
+		return cs.parent
+	}
+	return cs
+}
+
+func (cs *ChartSession) seriesID() string {
+	return "sds_" + strconv.Itoa(cs.index)
+}
+
+func (cs *ChartSession) symbolID() string {
+	return "sds_sym_" + strconv.Itoa(cs.index)
+}
+
+func (cs *ChartSession) turnaroundID() string {
+	return "s" + strconv.Itoa(cs.index)
+}
+
+// getAllSeries returns the first series of the chart session and the ones added with AddSeries
+func (cs *ChartSession) getAllSeries() []*ChartSession {
+	cs.mutex.RLock()
+	defer cs.mutex.RUnlock()
+
+	series := make([]*ChartSession, 0, len(cs.series)+1)
+	series = append(series, cs)
+	for _, child := range cs.series {
+		series = append(series, child)
+	}
+	return series
+}
+
+// getSeriesOfMessage returns the series the message is about, by its series or symbol id,
+// or the first one when the message is for the whole session
+func (cs *ChartSession) getSeriesOfMessage(payload []interface{}) *ChartSession {
+	if len(payload) < 2 {
+		return cs
+	}
+
+	id, _ := payload[1].(string)
+	for _, series := range cs.getAllSeries() {
+		if id == series.seriesID() || id == series.symbolID() {
+			return series
+		}
+	}
+	return cs
+}
+
+func (cs *ChartSession) removeSeries(series *ChartSession) {
+	cs.mutex.Lock()
+	delete(cs.series, series.seriesID())
+	cs.mutex.Unlock()
+
+	for _, st := range cs.getStudies() {
+		if st.chart == series {
+			cs.removeStudy(st)
+		}
+	}
+}
+
+func (cs *ChartSession) generateID() {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+
+	cs.id = ChartSessionPrefix + GetRandomString(cs.socket.sessionIDLength)
+}
+
+func (cs *ChartSession) sendCreateMessages() (err error) {
+	sessionID := cs.SessionID()
+
+	err = cs.socket.sendSocketMessage(getSocketMessage("chart_create_session", []string{sessionID, ""}))
+	if err != nil {
+		return
+	}
+
+	err = cs.socket.sendTimezone(sessionID)
+	if err != nil {
+		return
+	}
+
+	for _, series := range cs.getAllSeries() {
+		err = series.sendSeriesMessages(sessionID)
+		if err != nil {
+			return
+		}
+	}
+
+	for _, st := range cs.getStudies() {
+		err = st.sendCreateMessage(sessionID)
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// sendSeriesMessages resolves the symbol of the series and creates it
+func (cs *ChartSession) sendSeriesMessages(sessionID string) (err error) {
+	symbol, err := json.Marshal(cs.getSymbolDescriptor())
+	if err != nil {
+		return
+	}
+
+	messages := []*SocketMessage{
+		getSocketMessage("resolve_symbol", []string{sessionID, cs.symbolID(), "=" + string(symbol)}),
+		getSocketMessage("create_series", []interface{}{
+			sessionID, cs.seriesID(), cs.turnaroundID(), cs.symbolID(), string(cs.timeframe), cs.barCount, cs.barsRange,
+		}),
+	}
+	for _, msg := range messages {
+		err = cs.socket.sendSocketMessage(msg)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// getSymbolDescriptor returns the symbol resolved for the series, wrapped by the chart type and the replay when they're set
+func (cs *ChartSession) getSymbolDescriptor() interface{} {
+	var descriptor interface{} = cs.getResolvedSymbol()
+	if cs.chartType != ChartTypeCandles {
+		descriptor = map[string]interface{}{"symbol": descriptor, "type": cs.chartType, "inputs": cs.chartInputs}
+	}
+	if cs.replay != nil {
+		descriptor = map[string]interface{}{"replay": cs.replay.SessionID(), "symbol": descriptor}
+	}
+	return descriptor
+}
+
+// getResolvedSymbol returns the symbol of the series with its adjustment and session, the replays resolve the same one
+func (cs *ChartSession) getResolvedSymbol() map[string]string {
+	symbol := map[string]string{"symbol": cs.symbol, "adjustment": string(cs.adjustment), "session": "regular"}
+	if cs.extendedHours {
+		symbol["session"] = "extended"
+	}
+	if cs.backAdjusted {
+		symbol["backadjustment"] = "default"
+	}
+	return symbol
+}
+
+// handleMessage processes the messages sent by the server to the chart session, delivering them to the series they're about
+func (cs *ChartSession) handleMessage(message string, payload []interface{}) {
+	switch message {
+	case "timescale_update", "du":
+		if len(payload) < 2 {
+			return
+		}
+		updates, _ := payload[1].(map[string]interface{})
+		for _, series := range cs.getAllSeries() {
+			bars, _ := updates[series.seriesID()].(map[string]interface{})
+			series.handleCandles(decodeCandles(bars))
+		}
+		cs.handleStudyUpdates(updates)
+	case "study_error":
+		cs.handleStudyError(payload)
+	case "critical_error":
+		// The error is reported once, but all the series waiting for their history fail
+		err := newChartError(message, cs.symbol, payload)
+		for _, series := range cs.getAllSeries() {
+			series.fail(err)
+		}
+		cs.socket.OnErrorCallback(err, ChartSessionErrorContext)
+	default:
+		cs.getSeriesOfMessage(payload).handleSeriesMessage(message, payload)
+	}
+}
+
+// handleSeriesMessage processes the messages about the state of a series
+func (cs *ChartSession) handleSeriesMessage(message string, payload []interface{}) {
+	switch message {
+	case "symbol_resolved":
+		if len(payload) < 3 {
+			return
+		}
+		info, _ := payload[2].(map[string]interface{})
+		cs.handleResolvedSymbol(info)
+	case "series_completed":
+		cs.loadedOnce.Do(func() {
+			if cs.onLoaded != nil {
+				cs.onLoaded()
+			}
+			close(cs.loaded)
+		})
+		select {
+		case cs.completed <- struct{}{}:
+		default:
+		}
+	case "series_error", "symbol_error":
+		err := newChartError(message, cs.symbol, payload)
+		cs.fail(err)
+		cs.socket.OnErrorCallback(err, ChartSessionErrorContext)
+	}
+}
+
+// fail delivers the error to the ones waiting for the history of the series, if any
+func (cs *ChartSession) fail(err error) {
+	select {
+	case cs.failed <- err:
+	default:
+	}
+}
+
+// handleResolvedSymbol keeps the metadata of the symbol of the series, {"timezone": "America/New_York", "session": "0930-1600"...}
+func (cs *ChartSession) handleResolvedSymbol(info map[string]interface{}) {
+	frontContract, _ := info[FieldFrontContract].(string)
+	timezone, _ := info["timezone"].(string)
+	session, _ := info["session"].(string)
+
+	var location *time.Location
+	var hours *TradingHours
+	var err error
+	if timezone != "" {
+		location, err = time.LoadLocation(timezone)
+	}
+	if err == nil && session != "" {
+		hours, err = ParseTradingHours(session, location)
+	}
+	if err != nil {
+		cs.socket.OnErrorCallback(err, ChartSessionErrorContext)
+	}
+
+	symbolInfo := &SymbolInfo{}
+	err = mapstructure.Decode(info, symbolInfo)
+	if err != nil {
+		cs.socket.OnErrorCallback(err, ChartSessionErrorContext)
+		symbolInfo = nil
+	}
+
+	cs.mutex.Lock()
+	if frontContract != "" {
+		cs.frontContract = frontContract
+	}
+	if location != nil {
+		cs.location = location
+	}
+	if hours != nil {
+		cs.tradingHours = hours
+	}
+	if symbolInfo != nil {
+		cs.symbolInfo = symbolInfo
+	}
+	cs.mutex.Unlock()
+
+	if symbolInfo != nil && cs.onResolved != nil {
+		copied := *symbolInfo
+		cs.onResolved(cs.symbol, &copied)
+	}
+}
+
+// SymbolInfo returns the metadata of the resolved symbol of the series, nil until the symbol is resolved
+func (cs *ChartSession) SymbolInfo() *SymbolInfo {
+	cs.mutex.RLock()
+	defer cs.mutex.RUnlock()
+
+	if cs.symbolInfo == nil {
+		return nil
+	}
+	copied := *cs.symbolInfo
+	return &copied
+}
+
+// Location returns the time zone of the exchange of the symbol, UTC until the symbol is resolved
+func (cs *ChartSession) Location() *time.Location {
+	cs.mutex.RLock()
+	defer cs.mutex.RUnlock()
+
+	if cs.location == nil {
+		return time.UTC
+	}
+	return cs.location
+}
+
+// TradingHours returns the sessions of the symbol, nil until the symbol is resolved
+func (cs *ChartSession) TradingHours() *TradingHours {
+	cs.mutex.RLock()
+	defer cs.mutex.RUnlock()
+
+	return cs.tradingHours
+}
+
+// handleCandles delivers the candles of an update, marking as closed the ones followed by a newer one
+func (cs *ChartSession) handleCandles(candles []*Candle) {
+	location := cs.Location()
+	for i, candle := range candles {
+		candle.ExchangeTime = candle.Time.In(location)
+
+		// The pages of request_more_data have older candles, which are final and don't replace the forming one
+		if cs.forming != nil && candle.Time.Before(cs.forming.Time) {
+			candle.Closed = true
+			cs.onCandle(cs.symbol, candle)
+			continue
+		}
+
+		if cs.forming != nil && candle.Time.After(cs.forming.Time) {
+			closed := *cs.forming
+			closed.Closed = true
+			cs.onCandle(cs.symbol, &closed)
+		}
+
+		if i < len(candles)-1 && candles[i+1].Time.After(candle.Time) {
+			// The history candles are already final, there's no need to deliver them twice
+			candle.Closed = true
+			cs.forming = nil
+		} else {
+			cs.forming = candle
+		}
+		cs.onCandle(cs.symbol, candle)
+	}
+}
+
+// decodeCandles decodes the bars of a series update, {"s": [{"i": 0, "v": [time, open, high, low, close, volume]}]}
+func decodeCandles(series map[string]interface{}) (candles []*Candle) {
+	bars, _ := series["s"].([]interface{})
+	for _, bar := range bars {
+		fields, _ := bar.(map[string]interface{})
+		values, _ := fields["v"].([]interface{})
+		if len(values) < 5 {
+			continue
+		}
+
+		numbers := make([]float64, 6)
+		for i := 0; i < len(values) && i < len(numbers); i++ {
+			numbers[i], _ = values[i].(float64)
+		}
+		candles = append(candles, &Candle{
+			Time:   time.Unix(int64(numbers[0]), 0).UTC(),
+			Open:   numbers[1],
+			High:   numbers[2],
+			Low:    numbers[3],
+			Close:  numbers[4],
+			Volume: numbers[5],
+		})
+	}
+	return
+}
+
+func (s *Socket) registerChartSession(cs *ChartSession) {
+	s.chartSessionsMutex.Lock()
+	defer s.chartSessionsMutex.Unlock()
+
+	if s.chartSessions == nil {
+		s.chartSessions = map[string]*ChartSession{}
+	}
+	s.chartSessions[cs.SessionID()] = cs
+}
+
+func (s *Socket) unregisterChartSession(cs *ChartSession) {
+	s.chartSessionsMutex.Lock()
+	defer s.chartSessionsMutex.Unlock()
+
+	delete(s.chartSessions, cs.SessionID())
+}
+
+func (s *Socket) getChartSession(sessionID string) *ChartSession {
+	s.chartSessionsMutex.RLock()
+	defer s.chartSessionsMutex.RUnlock()
+
+	return s.chartSessions[sessionID]
+}
+
+func (s *Socket) getChartSessions() []*ChartSession {
+	s.chartSessionsMutex.RLock()
+	defer s.chartSessionsMutex.RUnlock()
+
+	sessions := make([]*ChartSession, 0, len(s.chartSessions))
+	for _, cs := range s.chartSessions {
+		sessions = append(sessions, cs)
+	}
+	return sessions
+}
+
+func (s *Socket) renewChartSessionID(cs *ChartSession) {
+	s.chartSessionsMutex.Lock()
+	defer s.chartSessionsMutex.Unlock()
+
+	delete(s.chartSessions, cs.SessionID())
+	cs.generateID()
+	s.chartSessions[cs.SessionID()] = cs
+}
@@ -0,0 +1,108 @@
+package tradingview
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// CircuitState ...
+type CircuitState string
+
+// CircuitClosed - Messages flow normally
+const CircuitClosed CircuitState = "closed"
+
+// CircuitOpen - Too many consecutive server errors, nothing is sent until the cooldown expires
+const CircuitOpen CircuitState = "open"
+
+// CircuitHalfOpen - The cooldown expired, the next server response decides if the circuit closes or opens again
+const CircuitHalfOpen CircuitState = "half-open"
+
+// ErrCircuitOpen is returned when trying to send a message while the circuit breaker is open
+var ErrCircuitOpen = errors.New("the circuit breaker is open due to repeated server errors")
+
+// OnCircuitStateChangeCallback ...
+type OnCircuitStateChangeCallback func(state CircuitState)
+
+type circuitBreaker struct {
+	mutex             sync.Mutex
+	threshold         int
+	cooldown          time.Duration
+	consecutiveErrors int
+	state             CircuitState
+	openedAt          time.Time
+	onStateChange     OnCircuitStateChangeCallback
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration, onStateChange OnCircuitStateChangeCallback) *circuitBreaker {
+	return &circuitBreaker{
+		threshold:     threshold,
+		cooldown:      cooldown,
+		state:         CircuitClosed,
+		onStateChange: onStateChange,
+	}
+}
+
+// recordError opens the circuit once the threshold is reached, or straight away if it was half-open
+func (cb *circuitBreaker) recordError() {
+	cb.mutex.Lock()
+	cb.consecutiveErrors++
+	shouldOpen := cb.state == CircuitHalfOpen || (cb.state == CircuitClosed && cb.consecutiveErrors >= cb.threshold)
+	if shouldOpen {
+		cb.openedAt = time.Now()
+	}
+	cb.mutex.Unlock()
+
+	if shouldOpen {
+		cb.setState(CircuitOpen)
+	}
+}
+
+func (cb *circuitBreaker) recordSuccess() {
+	cb.mutex.Lock()
+	cb.consecutiveErrors = 0
+	cb.mutex.Unlock()
+
+	cb.setState(CircuitClosed)
+}
+
+// allow tells if messages can be sent, moving the circuit to half-open when the cooldown has expired
+func (cb *circuitBreaker) allow() bool {
+	if cb.remainingCooldown() > 0 {
+		return false
+	}
+
+	cb.mutex.Lock()
+	isOpen := cb.state == CircuitOpen
+	cb.mutex.Unlock()
+
+	if isOpen {
+		cb.setState(CircuitHalfOpen)
+	}
+	return true
+}
+
+func (cb *circuitBreaker) remainingCooldown() time.Duration {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+
+	if cb.state != CircuitOpen {
+		return 0
+	}
+	remaining := cb.cooldown - time.Since(cb.openedAt)
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+func (cb *circuitBreaker) setState(state CircuitState) {
+	cb.mutex.Lock()
+	hasChanged := cb.state != state
+	cb.state = state
+	cb.mutex.Unlock()
+
+	if hasChanged && cb.onStateChange != nil {
+		cb.onStateChange(state)
+	}
+}
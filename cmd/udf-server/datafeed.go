@@ -0,0 +1,477 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	tradingview "github.com/marcos-gonalons/tradingview-scraper/v2"
+)
+
+// supportedResolutions are the resolutions of the charting library the datafeed serves
+var supportedResolutions = []string{
+	"1S", "5S", "15S", "30S", "1", "3", "5", "15", "30", "45", "60", "120", "180", "240", "1D", "1W", "1M",
+}
+
+// quoteFields are the quote fields of the /quotes endpoint
+var quoteFields = []string{
+	tradingview.FieldLastPrice, tradingview.FieldVolume, tradingview.FieldBid, tradingview.FieldAsk,
+	tradingview.FieldChange, tradingview.FieldChangePercent, tradingview.FieldOpenPrice, tradingview.FieldHighPrice,
+	tradingview.FieldLowPrice, tradingview.FieldPrevClosePrice,
+}
+
+// defaultSearchLimit is the number of results of /search when the request doesn't have a limit
+const defaultSearchLimit = 30
+
+// maxCountBackRequests is the most ranges requested to find the countback candles of /history, each one twice
+// as long as the previous one, the first one twice as long as the candles
+const maxCountBackRequests = 5
+
+type datafeed struct {
+	socket  tradingview.SocketInterface
+	timeout time.Duration
+	mux     *http.ServeMux
+
+	// symbols are the symbols resolved, by the name requested
+	symbols      map[string]*udfSymbol
+	symbolsMutex sync.RWMutex
+}
+
+type udfConfig struct {
+	SupportedResolutions   []string `json:"supported_resolutions"`
+	SupportsSearch         bool     `json:"supports_search"`
+	SupportsGroupRequest   bool     `json:"supports_group_request"`
+	SupportsMarks          bool     `json:"supports_marks"`
+	SupportsTimescaleMarks bool     `json:"supports_timescale_marks"`
+	SupportsTime           bool     `json:"supports_time"`
+	// SymbolsTypes are the types of the filter of the search
+	SymbolsTypes []*udfSymbolType `json:"symbols_types"`
+}
+
+type udfSymbolType struct {
+	Name  string                 `json:"name"`
+	Value tradingview.SymbolType `json:"value"`
+}
+
+type udfSymbol struct {
+	Name                 string   `json:"name"`
+	Ticker               string   `json:"ticker"`
+	Description          string   `json:"description"`
+	Type                 string   `json:"type"`
+	Session              string   `json:"session"`
+	Timezone             string   `json:"timezone"`
+	Exchange             string   `json:"exchange"`
+	ListedExchange       string   `json:"listed_exchange"`
+	Currency             string   `json:"currency_code,omitempty"`
+	MinMove              int      `json:"minmov"`
+	MinMove2             int      `json:"minmove2"`
+	Fractional           bool     `json:"fractional"`
+	PriceScale           int      `json:"pricescale"`
+	HasIntraday          bool     `json:"has_intraday"`
+	HasSeconds           bool     `json:"has_seconds"`
+	HasDaily             bool     `json:"has_daily"`
+	HasWeeklyAndMonthly  bool     `json:"has_weekly_and_monthly"`
+	SupportedResolutions []string `json:"supported_resolutions"`
+	VolumePrecision      int      `json:"volume_precision"`
+	DataStatus           string   `json:"data_status"`
+}
+
+type udfSearchResult struct {
+	Symbol      string `json:"symbol"`
+	FullName    string `json:"full_name"`
+	Description string `json:"description"`
+	Exchange    string `json:"exchange"`
+	Ticker      string `json:"ticker"`
+	Type        string `json:"type"`
+}
+
+// udfHistory is the response of /history, the bars as a column per value
+type udfHistory struct {
+	Status string    `json:"s"`
+	Time   []int64   `json:"t"`
+	Open   []float64 `json:"o"`
+	High   []float64 `json:"h"`
+	Low    []float64 `json:"l"`
+	Close  []float64 `json:"c"`
+	Volume []float64 `json:"v"`
+	// NextTime is the time of the last candle before the range, when there are none in it
+	NextTime int64 `json:"nextTime,omitempty"`
+}
+
+type udfQuotes struct {
+	Status string      `json:"s"`
+	Data   []*udfQuote `json:"d"`
+}
+
+type udfQuote struct {
+	Status       string                 `json:"s"`
+	Symbol       string                 `json:"n"`
+	ErrorMessage string                 `json:"errmsg,omitempty"`
+	Values       map[string]interface{} `json:"v,omitempty"`
+}
+
+type udfError struct {
+	Status       string `json:"s"`
+	ErrorMessage string `json:"errmsg"`
+}
+
+func newDatafeed(socket tradingview.SocketInterface, timeout time.Duration) *datafeed {
+	d := &datafeed{
+		socket:  socket,
+		timeout: timeout,
+		mux:     http.NewServeMux(),
+		symbols: map[string]*udfSymbol{},
+	}
+
+	d.mux.HandleFunc("/config", d.handleConfig)
+	d.mux.HandleFunc("/time", d.handleTime)
+	d.mux.HandleFunc("/symbols", d.handleSymbols)
+	d.mux.HandleFunc("/search", d.handleSearch)
+	d.mux.HandleFunc("/history", d.handleHistory)
+	d.mux.HandleFunc("/quotes", d.handleQuotes)
+	return d
+}
+
+func (d *datafeed) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// The charting library is usually served from another origin
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	d.mux.ServeHTTP(w, r)
+}
+
+func (d *datafeed) handleConfig(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, &udfConfig{
+		SupportedResolutions: supportedResolutions,
+		SupportsSearch:       true,
+		SupportsTime:         true,
+		SymbolsTypes: []*udfSymbolType{
+			{Name: "All types", Value: ""},
+			{Name: "Stock", Value: tradingview.SymbolTypeStock},
+			{Name: "Fund", Value: tradingview.SymbolTypeFund},
+			{Name: "Futures", Value: tradingview.SymbolTypeFutures},
+			{Name: "Forex", Value: tradingview.SymbolTypeForex},
+			{Name: "Crypto", Value: tradingview.SymbolTypeCrypto},
+			{Name: "Index", Value: tradingview.SymbolTypeIndex},
+			{Name: "Bond", Value: tradingview.SymbolTypeBond},
+			{Name: "Economic", Value: tradingview.SymbolTypeEconomic},
+		},
+	})
+}
+
+func (d *datafeed) handleTime(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain")
+	w.Write([]byte(strconv.FormatInt(time.Now().Unix(), 10)))
+}
+
+func (d *datafeed) handleSymbols(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), d.timeout)
+	defer cancel()
+
+	symbol, err := d.resolveSymbol(ctx, r.URL.Query().Get("symbol"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, symbol)
+}
+
+// resolveSymbol returns the metadata of the symbol, from the resolved symbol of a chart session.
+// The symbols without exchange (AAPL) are resolved with the symbol search.
+func (d *datafeed) resolveSymbol(ctx context.Context, name string) (symbol *udfSymbol, err error) {
+	d.symbolsMutex.RLock()
+	symbol, ok := d.symbols[name]
+	d.symbolsMutex.RUnlock()
+	if ok {
+		return
+	}
+
+	ticker, err := tradingview.ResolveSymbol(ctx, nil, name)
+	if err != nil {
+		return
+	}
+
+	var info *tradingview.SymbolInfo
+	_, err = d.socket.GetHistory(ctx, ticker, tradingview.Timeframe1Day, 1, tradingview.WithOnSymbolResolved(
+		func(symbol string, resolved *tradingview.SymbolInfo) {
+			info = resolved
+		},
+	))
+	if err != nil {
+		return
+	}
+	if info == nil {
+		return nil, errors.New("the symbol " + ticker + " was not resolved")
+	}
+
+	symbol = &udfSymbol{
+		Name:                 ticker[strings.Index(ticker, ":")+1:],
+		Ticker:               ticker,
+		Description:          info.Description,
+		Type:                 info.Type,
+		Session:              info.Session,
+		Timezone:             info.Timezone,
+		Exchange:             info.Exchange,
+		ListedExchange:       info.Exchange,
+		Currency:             info.Currency,
+		MinMove:              info.MinMove,
+		MinMove2:             info.MinMove2,
+		Fractional:           info.Fractional,
+		PriceScale:           info.PriceScale,
+		HasIntraday:          true,
+		HasSeconds:           info.HasSeconds,
+		HasDaily:             true,
+		HasWeeklyAndMonthly:  true,
+		SupportedResolutions: getSupportedResolutions(info.HasSeconds),
+		DataStatus:           "streaming",
+	}
+	if symbol.Session == "" {
+		symbol.Session = "24x7"
+	}
+	if symbol.Timezone == "" {
+		symbol.Timezone = "Etc/UTC"
+	}
+	if symbol.MinMove == 0 {
+		symbol.MinMove = 1
+	}
+	if symbol.PriceScale == 0 {
+		symbol.PriceScale = 100
+	}
+
+	d.symbolsMutex.Lock()
+	d.symbols[name] = symbol
+	d.symbolsMutex.Unlock()
+	return
+}
+
+func (d *datafeed) handleSearch(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), d.timeout)
+	defer cancel()
+
+	query := r.URL.Query()
+	limit, err := strconv.Atoi(query.Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = defaultSearchLimit
+	}
+
+	found, err := tradingview.SearchSymbols(
+		ctx,
+		nil,
+		query.Get("query"),
+		query.Get("exchange"),
+		tradingview.SymbolType(query.Get("type")),
+	)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	results := []*udfSearchResult{}
+	for _, result := range found {
+		if len(results) == limit {
+			break
+		}
+
+		results = append(results, &udfSearchResult{
+			Symbol:      result.Symbol,
+			FullName:    result.Ticker,
+			Description: result.Description,
+			Exchange:    result.Exchange,
+			Ticker:      result.Ticker,
+			Type:        result.Type,
+		})
+	}
+	writeJSON(w, results)
+}
+
+func (d *datafeed) handleHistory(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), d.timeout)
+	defer cancel()
+
+	query := r.URL.Query()
+	timeframe, err := getTimeframe(query.Get("resolution"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	from, err := strconv.ParseInt(query.Get("from"), 10, 64)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	to, err := strconv.ParseInt(query.Get("to"), 10, 64)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	countBack, _ := strconv.Atoi(query.Get("countback"))
+	symbol := query.Get("symbol")
+
+	// The countback takes priority over the start of the range
+	var candles []*tradingview.Candle
+	if countBack > 0 {
+		candles, err = d.getCountBackHistory(ctx, symbol, timeframe, time.Unix(to, 0), countBack)
+	} else {
+		candles, err = d.getHistoryRange(ctx, symbol, timeframe, time.Unix(from, 0), time.Unix(to, 0))
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if len(candles) == 0 {
+		// The countback already looked for the candles before the end of the range
+		noData := &udfHistory{Status: "no_data"}
+		if countBack == 0 {
+			previous, err := d.getCountBackHistory(ctx, symbol, timeframe, time.Unix(from, 0), 1)
+			if err == nil && len(previous) > 0 {
+				noData.NextTime = previous[0].Time.Unix()
+			}
+		}
+		writeJSON(w, noData)
+		return
+	}
+
+	history := &udfHistory{Status: "ok"}
+	for _, candle := range candles {
+		history.Time = append(history.Time, candle.Time.Unix())
+		history.Open = append(history.Open, candle.Open)
+		history.High = append(history.High, candle.High)
+		history.Low = append(history.Low, candle.Low)
+		history.Close = append(history.Close, candle.Close)
+		history.Volume = append(history.Volume, candle.Volume)
+	}
+	writeJSON(w, history)
+}
+
+// getHistoryRange returns the candles of the range, without the ones at its end
+func (d *datafeed) getHistoryRange(
+	ctx context.Context,
+	symbol string,
+	timeframe tradingview.Timeframe,
+	from time.Time,
+	to time.Time,
+) (candles []*tradingview.Candle, err error) {
+	candles, err = d.socket.GetHistoryRange(ctx, symbol, timeframe, from, to)
+	if err != nil {
+		return
+	}
+
+	// The end of the range is not included
+	for len(candles) > 0 && !candles[len(candles)-1].Time.Before(to) {
+		candles = candles[:len(candles)-1]
+	}
+	return
+}
+
+// getCountBackHistory returns the last countBack candles before to. The markets are closed part of the time,
+// so the range requested is made longer until it has enough candles.
+func (d *datafeed) getCountBackHistory(
+	ctx context.Context,
+	symbol string,
+	timeframe tradingview.Timeframe,
+	to time.Time,
+	countBack int,
+) (candles []*tradingview.Candle, err error) {
+	span := 2 * time.Duration(countBack) * timeframe.Duration()
+	for i := 0; i < maxCountBackRequests && len(candles) < countBack; i++ {
+		candles, err = d.getHistoryRange(ctx, symbol, timeframe, to.Add(-span), to)
+		if err != nil {
+			return
+		}
+		span *= 2
+	}
+
+	if len(candles) > countBack {
+		candles = candles[len(candles)-countBack:]
+	}
+	return
+}
+
+// handleQuotes subscribes to the symbols the first time they're requested, and returns their latest quote data
+func (d *datafeed) handleQuotes(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), d.timeout)
+	defer cancel()
+
+	quotes := &udfQuotes{Status: "ok", Data: []*udfQuote{}}
+	for _, symbol := range strings.Split(r.URL.Query().Get("symbols"), ",") {
+		if symbol == "" {
+			continue
+		}
+
+		quote := &udfQuote{Status: "ok", Symbol: symbol}
+		quotes.Data = append(quotes.Data, quote)
+
+		var err error
+		if !d.socket.IsSubscribed(symbol) {
+			err = d.socket.AddSymbolAndWait(ctx, symbol)
+		}
+		data, ok := d.socket.GetLatest(symbol)
+		if err != nil || !ok {
+			quote.Status = "error"
+			quote.ErrorMessage = "no data for " + symbol
+			if err != nil {
+				quote.ErrorMessage = err.Error()
+			}
+			continue
+		}
+		quote.Values = getQuoteValues(data)
+	}
+	writeJSON(w, quotes)
+}
+
+func getQuoteValues(data *tradingview.QuoteData) map[string]interface{} {
+	values := map[string]interface{}{}
+	fields := map[string]*float64{
+		"lp":               data.Price,
+		"volume":           data.Volume,
+		"bid":              data.Bid,
+		"ask":              data.Ask,
+		"ch":               data.Change,
+		"chp":              data.ChangePercent,
+		"open_price":       data.Open,
+		"high_price":       data.High,
+		"low_price":        data.Low,
+		"prev_close_price": data.PrevClose,
+	}
+	for name, value := range fields {
+		if value != nil {
+			values[name] = *value
+		}
+	}
+	return values
+}
+
+// getSupportedResolutions returns the resolutions of a symbol, the ones of seconds only when it has them
+func getSupportedResolutions(hasSeconds bool) (resolutions []string) {
+	for _, resolution := range supportedResolutions {
+		if hasSeconds || !strings.HasSuffix(resolution, "S") {
+			resolutions = append(resolutions, resolution)
+		}
+	}
+	return
+}
+
+// getTimeframe converts the resolution of the charting library (1, 60, 1D, 1W...) to the timeframe of TradingView
+func getTimeframe(resolution string) (tradingview.Timeframe, error) {
+	switch resolution {
+	case "1D":
+		resolution = "D"
+	case "1W":
+		resolution = "W"
+	case "1M":
+		resolution = "M"
+	}
+	return tradingview.ParseTimeframe(resolution)
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	writeJSON(w, &udfError{Status: "error", ErrorMessage: err.Error()})
+}
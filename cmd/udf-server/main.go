@@ -0,0 +1,65 @@
+// Command udf-server serves the market data of TradingView as a UDF datafeed, the HTTP protocol of the
+// datafeed of the charting library, so the charts of the charting library or lightweight-charts can use the scraper.
+//
+//	udf-server -addr :8080 -token <auth token>
+//
+// The datafeed URL of the charting library is then http://localhost:8080.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	tradingview "github.com/marcos-gonalons/tradingview-scraper/v2"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address of the HTTP server")
+	token := flag.String("token", "", "auth token of the TradingView account, the delayed data is used without it")
+	timeout := flag.Duration("timeout", 30*time.Second, "timeout of the requests to TradingView")
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	options := []tradingview.Option{
+		tradingview.WithReconnect(time.Second, time.Minute, 0),
+		tradingview.WithFields(quoteFields...),
+	}
+	if *token != "" {
+		options = append(options, tradingview.WithAuthToken(*token))
+	}
+
+	socket, err := tradingview.ConnectWithContext(
+		ctx,
+		func(symbol string, data *tradingview.QuoteData) {},
+		func(err error, context string) {
+			log.Printf("%s: %s", context, err)
+		},
+		options...,
+	)
+	if err != nil {
+		log.Fatal("Error while initializing the trading view socket -> " + err.Error())
+	}
+
+	server := &http.Server{Addr: *addr, Handler: newDatafeed(socket, *timeout)}
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+		socket.Shutdown(shutdownCtx)
+	}()
+
+	log.Printf("Serving the UDF datafeed on %s", *addr)
+	err = server.ListenAndServe()
+	if err != nil && err != http.ErrServerClosed {
+		log.Fatal(err)
+	}
+}
@@ -1,5 +1,20 @@
 package tradingview
 
+// DataEndpoint - Default endpoint, delayed data for most of the markets unless an auth token is used
+const DataEndpoint = "wss://data.tradingview.com/socket.io/websocket"
+
+// ProDataEndpoint - Endpoint used by the website for paid accounts with realtime data
+const ProDataEndpoint = "wss://prodata.tradingview.com/socket.io/websocket"
+
+// WidgetDataEndpoint - Endpoint used by the embeddable widgets
+const WidgetDataEndpoint = "wss://widgetdata.tradingview.com/socket.io/websocket"
+
+// UnauthorizedUserToken - Auth token used when none is given, it only gives access to delayed data for most of the markets
+const UnauthorizedUserToken = "unauthorized_user_token"
+
+// DefaultUserAgent ...
+const DefaultUserAgent = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/129.0.0.0 Safari/537.36"
+
 // InitErrorContext ...
 const InitErrorContext = "Initializing the connection"
 
@@ -44,3 +59,69 @@ const FinalPayloadHasMissingPropertiesErrorContext = "The final JSON payload doe
 
 // ReadMessageErrorContext ...
 const ReadMessageErrorContext = "Error while reading new messages through the socket connection"
+
+// ResubscribeErrorContext ...
+const ResubscribeErrorContext = "Subscribing again to the symbols after a reconnection"
+
+// ReconnectErrorContext ...
+const ReconnectErrorContext = "Reconnecting to the socket"
+
+// PingTimeoutErrorContext ...
+const PingTimeoutErrorContext = "The pong response was not received in time"
+
+// StaleConnectionErrorContext ...
+const StaleConnectionErrorContext = "No data has been received for too long"
+
+// RenewSessionErrorContext ...
+const RenewSessionErrorContext = "Creating a new quote session after the server invalidated the previous one"
+
+// MessageTooLargeErrorContext ...
+const MessageTooLargeErrorContext = "A message exceeded the read limit"
+
+// RestoreStateErrorContext ...
+const RestoreStateErrorContext = "Loading the saved subscription state"
+
+// SaveStateErrorContext ...
+const SaveStateErrorContext = "Saving the subscription state"
+
+// ConnectionClosedByServerErrorContext ...
+const ConnectionClosedByServerErrorContext = "The server closed the connection"
+
+// DefaultSessionPrefix is the prefix of the quote session ids, the same one used by the browser
+const DefaultSessionPrefix = "qs_"
+
+// ChartSessionPrefix is the prefix of the chart session ids
+const ChartSessionPrefix = "cs_"
+
+// ReplaySessionPrefix is the prefix of the replay session ids
+const ReplaySessionPrefix = "rs_"
+
+// DefaultSessionIDLength is the length of the random part of the session ids
+const DefaultSessionIDLength = 12
+
+// RefreshAuthTokenErrorContext ...
+const RefreshAuthTokenErrorContext = "Getting a new auth token after the server rejected the previous one"
+
+// SymbolErrorContext ...
+const SymbolErrorContext = "The server rejected a symbol"
+
+// ChartSessionErrorContext ...
+const ChartSessionErrorContext = "The server sent an error for a chart session"
+
+// ReplaySessionErrorContext ...
+const ReplaySessionErrorContext = "The server sent an error for a replay session"
+
+// StudyErrorContext ...
+const StudyErrorContext = "The server sent an error for a study"
+
+// HistoryCacheErrorContext ...
+const HistoryCacheErrorContext = "Reading or writing the history cache"
+
+// EconomicCalendarErrorContext ...
+const EconomicCalendarErrorContext = "Requesting the economic calendar"
+
+// NewsErrorContext ...
+const NewsErrorContext = "Requesting the news headlines"
+
+// WatchlistErrorContext ...
+const WatchlistErrorContext = "Syncing the symbols of a watchlist"
@@ -0,0 +1,113 @@
+package tradingview
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrNoCredentialsAvailable is returned by the credential pool when all its auth tokens are backed off
+var ErrNoCredentialsAvailable = errors.New("all the auth tokens of the credential pool are backed off")
+
+// authTokenThrottledReasons are the texts found in the error messages sent when an account is being throttled
+var authTokenThrottledReasons = []string{
+	"rate_limit",
+	"too_many_requests",
+	"throttled",
+}
+
+// CredentialPool rotates several auth tokens (accounts) across connections.
+// Every connection takes the next available token, and when an account is throttled or its token is rejected,
+// it's backed off for a while and the connection switches to the next one.
+type CredentialPool struct {
+	tokens      []string
+	backoff     time.Duration
+	backedOff   map[string]time.Time
+	nextAccount int
+	mutex       sync.Mutex
+}
+
+// NewCredentialPool creates a pool with the given auth tokens.
+// backoff is the time an account is not used after being throttled.
+func NewCredentialPool(tokens []string, backoff time.Duration) (pool *CredentialPool, err error) {
+	if len(tokens) == 0 {
+		return nil, errors.New("the credential pool needs at least one auth token")
+	}
+	if backoff <= 0 {
+		return nil, errors.New("the backoff of the credential pool must be positive")
+	}
+	for _, token := range tokens {
+		if token == "" {
+			return nil, errors.New("the auth tokens of the credential pool can't be empty")
+		}
+	}
+
+	pool = &CredentialPool{
+		tokens:    append([]string(nil), tokens...),
+		backoff:   backoff,
+		backedOff: map[string]time.Time{},
+	}
+	return
+}
+
+// Available returns how many auth tokens are not backed off
+func (p *CredentialPool) Available() (available int) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	now := time.Now()
+	for _, token := range p.tokens {
+		if !now.Before(p.backedOff[token]) {
+			available++
+		}
+	}
+	return
+}
+
+// BackOff stops using the auth token until the backoff of the pool has passed
+func (p *CredentialPool) BackOff(token string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.backedOff[token] = time.Now().Add(p.backoff)
+}
+
+// next returns the next auth token that is not backed off, in round-robin order
+func (p *CredentialPool) next() (token string, err error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	now := time.Now()
+	for i := 0; i < len(p.tokens); i++ {
+		candidate := p.tokens[(p.nextAccount+i)%len(p.tokens)]
+		if now.Before(p.backedOff[candidate]) {
+			continue
+		}
+
+		p.nextAccount = (p.nextAccount + i + 1) % len(p.tokens)
+		token = candidate
+		return
+	}
+
+	err = ErrNoCredentialsAvailable
+	return
+}
+
+// tokenProvider backs off the token currently used by the socket, if any, and returns the next one
+func (p *CredentialPool) tokenProvider(s *Socket) TokenProvider {
+	return func(ctx context.Context) (string, error) {
+		s.authMutex.RLock()
+		current := s.authToken
+		s.authMutex.RUnlock()
+
+		if current != "" {
+			p.BackOff(current)
+		}
+		return p.next()
+	}
+}
+
+func isAuthTokenThrottledMessage(message string, raw []byte) bool {
+	return isErrorMessageWithReason(message, raw, authTokenThrottledReasons)
+}
@@ -0,0 +1,157 @@
+package tradingview
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// CSVColumn - Column of the CSV files written by CSVWriter
+type CSVColumn string
+
+// CSVColumnTime ...
+const CSVColumnTime CSVColumn = "time"
+
+// CSVColumnExchangeTime is the time in the time zone of the exchange
+const CSVColumnExchangeTime CSVColumn = "exchange_time"
+
+// CSVColumnOpen ...
+const CSVColumnOpen CSVColumn = "open"
+
+// CSVColumnHigh ...
+const CSVColumnHigh CSVColumn = "high"
+
+// CSVColumnLow ...
+const CSVColumnLow CSVColumn = "low"
+
+// CSVColumnClose ...
+const CSVColumnClose CSVColumn = "close"
+
+// CSVColumnVolume ...
+const CSVColumnVolume CSVColumn = "volume"
+
+// CSVColumnClosed tells if the candle is final
+const CSVColumnClosed CSVColumn = "closed"
+
+// DefaultCSVColumns are the columns used when none are given
+var DefaultCSVColumns = []CSVColumn{
+	CSVColumnTime, CSVColumnOpen, CSVColumnHigh, CSVColumnLow, CSVColumnClose, CSVColumnVolume,
+}
+
+// CSVTimeUnix writes the times as unix seconds, the default
+const CSVTimeUnix = "unix"
+
+// CSVTimeUnixMilli writes the times as unix milliseconds
+const CSVTimeUnixMilli = "unix_ms"
+
+// CSVWriter writes candles as CSV, with a header with the names of the columns
+type CSVWriter struct {
+	writer     *csv.Writer
+	columns    []CSVColumn
+	timeFormat string
+
+	headerWritten bool
+	mutex         sync.Mutex
+}
+
+// NewCSVWriter creates a writer of the given columns, DefaultCSVColumns if nil. The time format is CSVTimeUnix,
+// CSVTimeUnixMilli or a layout of the time package, as time.RFC3339.
+func NewCSVWriter(w io.Writer, columns []CSVColumn, timeFormat string) (writer *CSVWriter, err error) {
+	if w == nil {
+		return nil, errors.New("the writer can't be nil")
+	}
+	if len(columns) == 0 {
+		columns = DefaultCSVColumns
+	}
+	for _, column := range columns {
+		switch column {
+		case CSVColumnTime, CSVColumnExchangeTime, CSVColumnOpen, CSVColumnHigh, CSVColumnLow, CSVColumnClose,
+			CSVColumnVolume, CSVColumnClosed:
+		default:
+			return nil, errors.New("unknown CSV column " + string(column))
+		}
+	}
+	if timeFormat == "" {
+		timeFormat = CSVTimeUnix
+	}
+
+	writer = &CSVWriter{writer: csv.NewWriter(w), columns: columns, timeFormat: timeFormat}
+	return
+}
+
+// WriteCandles writes the candles, the header first if it hasn't been written yet
+func (w *CSVWriter) WriteCandles(candles []*Candle) (err error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if !w.headerWritten {
+		header := make([]string, len(w.columns))
+		for i, column := range w.columns {
+			header[i] = string(column)
+		}
+		err = w.writer.Write(header)
+		if err != nil {
+			return
+		}
+		w.headerWritten = true
+	}
+
+	for _, candle := range candles {
+		err = w.writer.Write(w.getRecord(candle))
+		if err != nil {
+			return
+		}
+	}
+
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+// WriteCandle writes a single candle, to dump the streaming ones from the candle callback.
+// Only the closed ones are usually wanted, see Candle.Closed.
+func (w *CSVWriter) WriteCandle(candle *Candle) error {
+	return w.WriteCandles([]*Candle{candle})
+}
+
+func (w *CSVWriter) getRecord(candle *Candle) []string {
+	record := make([]string, len(w.columns))
+	for i, column := range w.columns {
+		switch column {
+		case CSVColumnTime:
+			record[i] = w.formatTime(candle.Time)
+		case CSVColumnExchangeTime:
+			record[i] = w.formatTime(candle.ExchangeTime)
+		case CSVColumnOpen:
+			record[i] = formatCSVFloat(candle.Open)
+		case CSVColumnHigh:
+			record[i] = formatCSVFloat(candle.High)
+		case CSVColumnLow:
+			record[i] = formatCSVFloat(candle.Low)
+		case CSVColumnClose:
+			record[i] = formatCSVFloat(candle.Close)
+		case CSVColumnVolume:
+			record[i] = formatCSVFloat(candle.Volume)
+		case CSVColumnClosed:
+			record[i] = strconv.FormatBool(candle.Closed)
+		}
+	}
+	return record
+}
+
+func (w *CSVWriter) formatTime(t time.Time) string {
+	switch w.timeFormat {
+	case CSVTimeUnix:
+		return strconv.FormatInt(t.Unix(), 10)
+	case CSVTimeUnixMilli:
+		return strconv.FormatInt(t.UnixNano()/int64(time.Millisecond), 10)
+	default:
+		return t.Format(w.timeFormat)
+	}
+}
+
+func formatCSVFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,80 @@
+package tradingview
+
+import "sync"
+
+// FXRatesPrefix is the prefix of the symbols used to get the exchange rates between currencies
+const FXRatesPrefix = "FX_IDC:"
+
+// currencyConverter converts the prices into the base currency using the exchange rates streamed by its own quote session
+type currencyConverter struct {
+	base    string
+	session *QuoteSession
+
+	// currencies has the currency of every symbol, rates the rate from every currency to the base one
+	currencies map[string]string
+	rates      map[string]float64
+	requested  map[string]bool
+	mutex      sync.Mutex
+}
+
+func (s *Socket) newCurrencyConverter(base string) *currencyConverter {
+	c := &currencyConverter{
+		base:       base,
+		currencies: map[string]string{},
+		rates:      map[string]float64{base: 1},
+		requested:  map[string]bool{base: true},
+	}
+	c.session = s.newQuoteSession(c.onRate, []string{FieldLastPrice})
+	return c
+}
+
+// onRate is the callback of the quote session of the exchange rates
+func (c *currencyConverter) onRate(symbol string, data *QuoteData) {
+	if data.Price == nil {
+		return
+	}
+
+	currency := symbol[len(FXRatesPrefix) : len(symbol)-len(c.base)]
+	c.mutex.Lock()
+	c.rates[currency] = *data.Price
+	c.mutex.Unlock()
+}
+
+// convert fills the converted prices of the update, subscribing to the exchange rate of the currency of the symbol
+// the first time it's seen. Nothing is converted until the rate has been received.
+func (c *currencyConverter) convert(symbol string, data *QuoteData) {
+	c.mutex.Lock()
+	if data.Currency != nil {
+		c.currencies[symbol] = *data.Currency
+	}
+	currency := c.currencies[symbol]
+	rate, hasRate := c.rates[currency]
+	mustRequest := currency != "" && !c.requested[currency]
+	if mustRequest {
+		c.requested[currency] = true
+	}
+	c.mutex.Unlock()
+
+	if mustRequest {
+		// The symbol is kept even if sending fails, so it's subscribed again on the next reconnection
+		c.session.AddSymbol(FXRatesPrefix + currency + c.base)
+		return
+	}
+	if !hasRate {
+		return
+	}
+
+	data.ConvertedCurrency = c.base
+	data.ConvertedPrice = convertPrice(data.Price, rate)
+	data.ConvertedBid = convertPrice(data.Bid, rate)
+	data.ConvertedAsk = convertPrice(data.Ask, rate)
+}
+
+func convertPrice(price *float64, rate float64) *float64 {
+	if price == nil {
+		return nil
+	}
+
+	converted := *price * rate
+	return &converted
+}
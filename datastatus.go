@@ -0,0 +1,73 @@
+package tradingview
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DataStatus - Quality of the data feed of a symbol
+type DataStatus string
+
+// DataStatusRealtime ...
+const DataStatusRealtime DataStatus = "realtime"
+
+// DataStatusDelayed ...
+const DataStatusDelayed DataStatus = "delayed"
+
+// DataStatusEndOfDay ...
+const DataStatusEndOfDay DataStatus = "endofday"
+
+// DataStatusUnknown is returned when the update mode hasn't been received yet
+const DataStatusUnknown DataStatus = ""
+
+// getDataStatus converts the update_mode quote field (streaming, delayed_streaming_900, endofday...) into a DataStatus
+func getDataStatus(updateMode string) DataStatus {
+	switch {
+	case updateMode == "streaming":
+		return DataStatusRealtime
+	case strings.HasPrefix(updateMode, "delayed"):
+		return DataStatusDelayed
+	case updateMode == "endofday":
+		return DataStatusEndOfDay
+	default:
+		return DataStatusUnknown
+	}
+}
+
+// DataStatus tells whether the prices of the symbol are realtime, delayed or end of day.
+// The update mode is usually only sent in the first message of a symbol and when it changes,
+// so DataStatusUnknown is returned for the updates that don't include it.
+func (d *QuoteData) DataStatus() DataStatus {
+	if d == nil || d.UpdateMode == nil {
+		return DataStatusUnknown
+	}
+	return getDataStatus(*d.UpdateMode)
+}
+
+// DataDelay returns how much the data of the symbol is delayed, taken from the delay field
+// or from the update mode (delayed_streaming_900 means 15 minutes). ok is false when the update includes neither.
+func (d *QuoteData) DataDelay() (delay time.Duration, ok bool) {
+	if d == nil {
+		return
+	}
+	if d.Delay != nil {
+		return time.Duration(*d.Delay * float64(time.Second)), true
+	}
+	if d.UpdateMode == nil {
+		return
+	}
+
+	switch getDataStatus(*d.UpdateMode) {
+	case DataStatusRealtime:
+		return 0, true
+	case DataStatusDelayed:
+		index := strings.LastIndex(*d.UpdateMode, "_")
+		seconds, err := strconv.Atoi((*d.UpdateMode)[index+1:])
+		if err != nil {
+			return
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	return
+}
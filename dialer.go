@@ -0,0 +1,37 @@
+package tradingview
+
+import (
+	"net/http"
+
+	"github.com/gorilla/websocket"
+)
+
+// DialerFactory returns the dialer used every time the socket connects, including the reconnections
+type DialerFactory func() *websocket.Dialer
+
+// getDialer returns a copy of the configured dialer, so the socket options can be applied on top of it
+// without modifying the dialer owned by the caller
+func (s *Socket) getDialer() *websocket.Dialer {
+	dialer := &websocket.Dialer{}
+	if s.dialerFactory != nil {
+		if d := s.dialerFactory(); d != nil {
+			copied := *d
+			dialer = &copied
+		}
+	}
+
+	if s.compression {
+		dialer.EnableCompression = true
+	}
+	if s.readBufferSize > 0 {
+		dialer.ReadBufferSize = s.readBufferSize
+	}
+	if s.writeBufferSize > 0 {
+		dialer.WriteBufferSize = s.writeBufferSize
+	}
+	if s.proxyURL != nil {
+		dialer.Proxy = http.ProxyURL(s.proxyURL)
+	}
+
+	return dialer
+}
@@ -0,0 +1,133 @@
+package tradingview
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Scanner columns of the earnings, the fq ones are the last quarter reported and the next_fq ones the next quarter
+const earningsReleaseDateColumn = "earnings_release_date"
+const earningsReleaseNextDateColumn = "earnings_release_next_date"
+const earningsPerShareColumn = "earnings_per_share_fq"
+const earningsPerShareForecastColumn = "earnings_per_share_forecast_fq"
+const earningsPerShareNextForecastColumn = "earnings_per_share_forecast_next_fq"
+const revenueColumn = "revenue_fq"
+const revenueForecastColumn = "revenue_forecast_fq"
+const revenueNextForecastColumn = "revenue_forecast_next_fq"
+
+// EarningsEvent - Earnings report of a company, upcoming or already reported
+type EarningsEvent struct {
+	Symbol      string
+	Description string
+	Date        time.Time
+	// Reported is false for the upcoming reports, which only have the estimates
+	Reported bool
+	// EPS and Revenue are nil until reported, and the estimates when there are none
+	EPS             *float64
+	EPSEstimate     *float64
+	Revenue         *float64
+	RevenueEstimate *float64
+	MarketCap       float64
+}
+
+// EPSSurprise returns the difference between the reported EPS and the estimate, as a percentage of the estimate.
+// ok is false when the report doesn't have both.
+func (e *EarningsEvent) EPSSurprise() (surprise float64, ok bool) {
+	if e.EPS == nil || e.EPSEstimate == nil || *e.EPSEstimate == 0 {
+		return
+	}
+	estimate := *e.EPSEstimate
+	if estimate < 0 {
+		estimate = -estimate
+	}
+	return (*e.EPS - *e.EPSEstimate) / estimate * 100, true
+}
+
+// GetLatestEarnings returns the latest and the next earnings reports of the companies of the market
+// (america, germany...) that are between from and to, oldest first: the next ones with their estimates
+// and the latest ones with the actual values. The scanner only has those two reports of every company,
+// the older ones are not available.
+// The exchange (NASDAQ, NYSE...) can be empty for all of them, and minMarketCap 0 for all the companies.
+// The HTTP client can be nil to use http.DefaultClient.
+func GetLatestEarnings(
+	ctx context.Context,
+	client *http.Client,
+	market string,
+	from time.Time,
+	to time.Time,
+	exchange string,
+	minMarketCap float64,
+) (events []*EarningsEvent, err error) {
+	if !from.Before(to) {
+		return nil, errors.New("the start of the range must be before its end")
+	}
+
+	newScreener := func(dateColumn string) *Screener {
+		screener := NewScreener(client, market).
+			Columns(
+				ScreenerColumnDescription, ScreenerColumnMarketCap, dateColumn,
+				earningsPerShareColumn, earningsPerShareForecastColumn, earningsPerShareNextForecastColumn,
+				revenueColumn, revenueForecastColumn, revenueNextForecastColumn,
+			).
+			Where(dateColumn, FilterInRange, []interface{}{from.Unix(), to.Unix()})
+		if exchange != "" {
+			screener.Where(ScreenerColumnExchange, FilterEqual, strings.ToUpper(exchange))
+		}
+		if minMarketCap > 0 {
+			screener.Where(ScreenerColumnMarketCap, FilterGreaterOrEqual, minMarketCap)
+		}
+		return screener
+	}
+
+	reported, err := newScreener(earningsReleaseDateColumn).ScanAll(ctx)
+	if err != nil {
+		return
+	}
+	for _, row := range reported {
+		events = append(events, newEarningsEvent(row, earningsReleaseDateColumn, true))
+	}
+
+	upcoming, err := newScreener(earningsReleaseNextDateColumn).ScanAll(ctx)
+	if err != nil {
+		return
+	}
+	for _, row := range upcoming {
+		events = append(events, newEarningsEvent(row, earningsReleaseNextDateColumn, false))
+	}
+
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Date.Before(events[j].Date) })
+	return
+}
+
+func newEarningsEvent(row *ScreenerRow, dateColumn string, reported bool) *EarningsEvent {
+	event := &EarningsEvent{Symbol: row.Symbol, Reported: reported}
+	event.Description, _ = row.String(ScreenerColumnDescription)
+	event.MarketCap, _ = row.Float(ScreenerColumnMarketCap)
+	if date, ok := row.Float(dateColumn); ok {
+		event.Date = time.Unix(int64(date), 0)
+	}
+
+	if reported {
+		event.EPS = getRowValue(row, earningsPerShareColumn)
+		event.EPSEstimate = getRowValue(row, earningsPerShareForecastColumn)
+		event.Revenue = getRowValue(row, revenueColumn)
+		event.RevenueEstimate = getRowValue(row, revenueForecastColumn)
+	} else {
+		event.EPSEstimate = getRowValue(row, earningsPerShareNextForecastColumn)
+		event.RevenueEstimate = getRowValue(row, revenueNextForecastColumn)
+	}
+	return event
+}
+
+// getRowValue returns the numeric value of the column, nil when the row doesn't have it
+func getRowValue(row *ScreenerRow, column string) *float64 {
+	value, ok := row.Float(column)
+	if !ok {
+		return nil
+	}
+	return &value
+}
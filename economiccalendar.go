@@ -0,0 +1,197 @@
+package tradingview
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EconomicCalendarURL ...
+const EconomicCalendarURL = "https://economic-calendar.tradingview.com/events"
+
+// EconomicCalendarLiveInterval is the wait between the requests of WatchEconomicCalendar when an event is about to be released,
+// or it's been released and its actual value is not there yet
+const EconomicCalendarLiveInterval = 10 * time.Second
+
+// EconomicCalendarIdleInterval is the longest wait between the requests of WatchEconomicCalendar,
+// when no event is going to be released soon
+const EconomicCalendarIdleInterval = 15 * time.Minute
+
+// economicCalendarReleaseWindow is how long WatchEconomicCalendar keeps polling for the actual value of an event after its time
+const economicCalendarReleaseWindow = time.Hour
+
+// economicCalendarLookAhead is the range of the events requested by WatchEconomicCalendar, from now
+const economicCalendarLookAhead = 24 * time.Hour
+
+// Importance - Expected impact of an economic event on the markets
+type Importance int
+
+// ImportanceLow ...
+const ImportanceLow Importance = -1
+
+// ImportanceMedium ...
+const ImportanceMedium Importance = 0
+
+// ImportanceHigh ...
+const ImportanceHigh Importance = 1
+
+// EconomicEvent - Release of an economic indicator in the economic calendar
+type EconomicEvent struct {
+	ID         string     `json:"id"`
+	Title      string     `json:"title"`
+	Country    string     `json:"country"`
+	Currency   string     `json:"currency"`
+	Indicator  string     `json:"indicator"`
+	Category   string     `json:"category"`
+	Importance Importance `json:"importance"`
+	// Time is when the value is released
+	Time time.Time `json:"date"`
+	// Period is the period the value is for, as "Dec" or "Q4"
+	Period string `json:"period"`
+	// Ticker is the macro series of the indicator, see GetEconomicSeries
+	Ticker string `json:"ticker"`
+	Source string `json:"source"`
+	// Unit of the values, as "%"
+	Unit string `json:"unit"`
+	// Actual is nil until the value is released, Forecast and Previous when there are none
+	Actual   *float64 `json:"actual"`
+	Forecast *float64 `json:"forecast"`
+	Previous *float64 `json:"previous"`
+	Comment  string   `json:"comment"`
+}
+
+type economicCalendarResponse struct {
+	Status string           `json:"status"`
+	Result []*EconomicEvent `json:"result"`
+}
+
+// GetEconomicCalendar returns the economic events released between from and to, oldest first.
+// The countries are the codes of the website (US, EU, GB, JP...), all of them when there are none,
+// and only the events of at least minImportance are returned.
+// The HTTP client can be nil to use http.DefaultClient.
+func GetEconomicCalendar(
+	ctx context.Context,
+	client *http.Client,
+	from time.Time,
+	to time.Time,
+	countries []string,
+	minImportance Importance,
+) (events []*EconomicEvent, err error) {
+	if !from.Before(to) {
+		return nil, errors.New("the start of the range must be before its end")
+	}
+
+	query := url.Values{}
+	query.Set("from", from.UTC().Format("2006-01-02T15:04:05.000Z"))
+	query.Set("to", to.UTC().Format("2006-01-02T15:04:05.000Z"))
+	query.Set("minImportance", strconv.Itoa(int(minImportance)))
+	if len(countries) > 0 {
+		query.Set("countries", strings.ToUpper(strings.Join(countries, ",")))
+	}
+
+	req, err := newHTTPRequest(ctx, http.MethodGet, EconomicCalendarURL+"?"+query.Encode(), nil)
+	if err != nil {
+		return
+	}
+
+	var res *economicCalendarResponse
+	_, err = doHTTPRequest(client, req, &res)
+	if err != nil {
+		return
+	}
+	if res == nil || res.Status != "ok" {
+		return nil, errors.New("unexpected response from the economic calendar")
+	}
+
+	return res.Result, nil
+}
+
+// WatchEconomicCalendar calls onRelease with every event of the next 24 hours when its actual value is released,
+// until the context is done. The calendar is requested every EconomicCalendarLiveInterval around the release times,
+// and less often the rest of the time. The errors of the requests are sent to onError, and it keeps trying.
+func WatchEconomicCalendar(
+	ctx context.Context,
+	client *http.Client,
+	countries []string,
+	minImportance Importance,
+	onRelease OnEconomicEventCallback,
+	onError OnErrorCallback,
+) (err error) {
+	if onRelease == nil || onError == nil {
+		return errors.New("the callbacks of the economic calendar can't be nil")
+	}
+
+	// released are the times of the events already delivered, or released before the first request, by id
+	released := map[string]time.Time{}
+	first := true
+	for {
+		now := time.Now()
+		wait := EconomicCalendarLiveInterval
+
+		var events []*EconomicEvent
+		events, err = GetEconomicCalendar(
+			ctx,
+			client,
+			now.Add(-economicCalendarReleaseWindow),
+			now.Add(economicCalendarLookAhead),
+			countries,
+			minImportance,
+		)
+		if err != nil && ctx.Err() == nil {
+			onError(err, EconomicCalendarErrorContext)
+		}
+		if err == nil {
+			for _, event := range events {
+				if _, isReleased := released[event.ID]; event.Actual != nil && !isReleased {
+					released[event.ID] = event.Time
+					if !first {
+						onRelease(event)
+					}
+				}
+			}
+			first = false
+			wait = getEconomicCalendarWait(events, released, now)
+			pruneReleasedEvents(released, now)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// getEconomicCalendarWait returns how long to wait before requesting the calendar again:
+// until the next release, or the live interval when one is pending
+func getEconomicCalendarWait(events []*EconomicEvent, released map[string]time.Time, now time.Time) time.Duration {
+	wait := EconomicCalendarIdleInterval
+	for _, event := range events {
+		if _, isReleased := released[event.ID]; isReleased || event.Time.Before(now.Add(-economicCalendarReleaseWindow)) {
+			continue
+		}
+
+		untilRelease := event.Time.Sub(now)
+		if untilRelease < EconomicCalendarLiveInterval {
+			return EconomicCalendarLiveInterval
+		}
+		if untilRelease < wait {
+			wait = untilRelease
+		}
+	}
+	return wait
+}
+
+// pruneReleasedEvents forgets the events that are too old to be requested again, so the ones delivered don't pile up.
+// The events are kept a look ahead longer than the release window, in case the server changes their times.
+func pruneReleasedEvents(released map[string]time.Time, now time.Time) {
+	for id, eventTime := range released {
+		if eventTime.Before(now.Add(-economicCalendarReleaseWindow - economicCalendarLookAhead)) {
+			delete(released, id)
+		}
+	}
+}
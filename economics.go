@@ -0,0 +1,46 @@
+package tradingview
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+// EconomicsPrefix is the exchange of the macroeconomic series, as ECONOMICS:USCPI or ECONOMICS:USGDP
+const EconomicsPrefix = "ECONOMICS:"
+
+// EconomicValue - Value of a macroeconomic series for a period
+type EconomicValue struct {
+	Time  time.Time
+	Value float64
+}
+
+// GetEconomicSeries returns the last count values of the macroeconomic indicator (USCPI, USGDP, USUR...), oldest first.
+// The prefix ECONOMICS: is added when it's missing. The timeframe is the period of the values,
+// usually Timeframe1Month, or "3M" for the quarterly ones.
+func (s *Socket) GetEconomicSeries(
+	ctx context.Context,
+	indicator string,
+	timeframe Timeframe,
+	count int,
+) (values []*EconomicValue, err error) {
+	indicator = strings.ToUpper(strings.TrimSpace(indicator))
+	if indicator == "" {
+		return nil, errors.New("the indicator can't be empty")
+	}
+	if !strings.Contains(indicator, ":") {
+		indicator = EconomicsPrefix + indicator
+	}
+
+	candles, err := s.GetHistory(ctx, indicator, timeframe, count)
+	if err != nil {
+		return
+	}
+
+	values = make([]*EconomicValue, 0, len(candles))
+	for _, candle := range candles {
+		values = append(values, &EconomicValue{Time: candle.Time, Value: candle.Close})
+	}
+	return
+}
@@ -0,0 +1,119 @@
+package tradingview
+
+import (
+	"errors"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+type endpointHealth struct {
+	url                 string
+	consecutiveFailures int
+	lastSuccessAt       time.Time
+}
+
+// endpointPool keeps track of how every candidate endpoint is behaving,
+// so the healthiest one is tried first on every (re)connection
+type endpointPool struct {
+	mutex     sync.Mutex
+	endpoints []*endpointHealth
+}
+
+func newEndpointPool(urls []string) *endpointPool {
+	pool := &endpointPool{}
+	for _, u := range urls {
+		pool.endpoints = append(pool.endpoints, &endpointHealth{url: u})
+	}
+
+	return pool
+}
+
+// ordered returns the endpoints with the fewest consecutive failures first.
+// On a tie, the most recently successful one wins, and then the original order is kept.
+func (p *endpointPool) ordered() []string {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	endpoints := make([]*endpointHealth, len(p.endpoints))
+	copy(endpoints, p.endpoints)
+	sort.SliceStable(endpoints, func(i, j int) bool {
+		if endpoints[i].consecutiveFailures != endpoints[j].consecutiveFailures {
+			return endpoints[i].consecutiveFailures < endpoints[j].consecutiveFailures
+		}
+		return endpoints[i].lastSuccessAt.After(endpoints[j].lastSuccessAt)
+	})
+
+	urls := make([]string, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		urls = append(urls, endpoint.url)
+	}
+	return urls
+}
+
+func (p *endpointPool) recordSuccess(u string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for _, endpoint := range p.endpoints {
+		if endpoint.url == u {
+			endpoint.consecutiveFailures = 0
+			endpoint.lastSuccessAt = time.Now()
+		}
+	}
+}
+
+func (p *endpointPool) recordFailure(u string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for _, endpoint := range p.endpoints {
+		if endpoint.url == u {
+			endpoint.consecutiveFailures++
+		}
+	}
+}
+
+// dial tries every endpoint, healthiest first, until the handshake succeeds with one of them
+func (s *Socket) dial() (transport Transport, err error) {
+	var failures []string
+
+	transportFactory := s.transportFactory
+	if transportFactory == nil {
+		transportFactory = s.dialWebsocket
+	}
+
+	for _, endpoint := range s.endpoints.ordered() {
+		transport, err = transportFactory(s.ctx, endpoint, s.getHeaders(endpoint))
+		if err == nil {
+			s.endpoints.recordSuccess(endpoint)
+			s.endpoint = endpoint
+			return
+		}
+
+		s.endpoints.recordFailure(endpoint)
+		failures = append(failures, endpoint+": "+err.Error())
+		if s.ctx.Err() != nil {
+			break
+		}
+	}
+
+	if len(failures) > 1 {
+		err = errors.New("all the endpoints failed -> " + strings.Join(failures, " | "))
+	}
+	return
+}
+
+func validateEndpoint(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "ws" && u.Scheme != "wss" {
+		return errors.New("the endpoint must be a ws:// or wss:// url")
+	}
+
+	return nil
+}
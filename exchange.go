@@ -0,0 +1,73 @@
+package tradingview
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// GetExchangeSymbols returns all the symbols of the exchange (BINANCE, NASDAQ...) listed by the scanner of the market
+// (crypto, america, forex...). The HTTP client can be nil to use http.DefaultClient.
+func GetExchangeSymbols(ctx context.Context, client *http.Client, market string, exchange string) (symbols []string, err error) {
+	if market == "" || exchange == "" {
+		return nil, errors.New("the market and the exchange can't be empty")
+	}
+
+	rows, err := NewScreener(client, market).
+		Columns(ScreenerColumnName).
+		Where(ScreenerColumnExchange, FilterEqual, strings.ToUpper(exchange)).
+		ScanAll(ctx)
+	if err != nil {
+		return
+	}
+
+	for _, row := range rows {
+		symbols = append(symbols, row.Symbol)
+	}
+	return
+}
+
+// AddExchange subscribes to all the symbols of the exchange, spread across the sockets of the pool.
+// They're added in batches of batchSize symbols, waiting interval between them to not flood the server.
+// The symbols added are returned, even if it fails halfway.
+func (p *SocketPool) AddExchange(
+	ctx context.Context,
+	client *http.Client,
+	market string,
+	exchange string,
+	batchSize int,
+	interval time.Duration,
+) (added []string, err error) {
+	if batchSize <= 0 {
+		return nil, errors.New("the batch size must be positive")
+	}
+
+	symbols, err := GetExchangeSymbols(ctx, client, market, exchange)
+	if err != nil {
+		return
+	}
+
+	for from := 0; from < len(symbols); from += batchSize {
+		if from > 0 {
+			select {
+			case <-ctx.Done():
+				err = ctx.Err()
+				return
+			case <-time.After(interval):
+			}
+		}
+
+		to := from + batchSize
+		if to > len(symbols) {
+			to = len(symbols)
+		}
+		err = p.AddSymbols(symbols[from:to])
+		if err != nil {
+			return
+		}
+		added = append(added, symbols[from:to]...)
+	}
+	return
+}
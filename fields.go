@@ -0,0 +1,162 @@
+package tradingview
+
+// FieldLastPrice - Last price
+const FieldLastPrice = "lp"
+
+// FieldLastPriceTime - Unix time of the last trade
+const FieldLastPriceTime = "lp_time"
+
+// FieldVolume - Volume of the day
+const FieldVolume = "volume"
+
+// FieldBid ...
+const FieldBid = "bid"
+
+// FieldAsk ...
+const FieldAsk = "ask"
+
+// FieldBidSize ...
+const FieldBidSize = "bid_size"
+
+// FieldAskSize ...
+const FieldAskSize = "ask_size"
+
+// FieldChange - Change of the day
+const FieldChange = "ch"
+
+// FieldChangePercent - Change percent of the day
+const FieldChangePercent = "chp"
+
+// FieldOpenPrice ...
+const FieldOpenPrice = "open_price"
+
+// FieldHighPrice ...
+const FieldHighPrice = "high_price"
+
+// FieldLowPrice ...
+const FieldLowPrice = "low_price"
+
+// FieldPrevClosePrice ...
+const FieldPrevClosePrice = "prev_close_price"
+
+// FieldAverageVolume ...
+const FieldAverageVolume = "average_volume"
+
+// FieldExtendedPrice - Price in the pre-market or after-hours session
+const FieldExtendedPrice = "rtc"
+
+// FieldExtendedPriceTime - Unix time of the last trade in the pre-market or after-hours session
+const FieldExtendedPriceTime = "rtc_time"
+
+// FieldExtendedChange ...
+const FieldExtendedChange = "rch"
+
+// FieldExtendedChangePercent ...
+const FieldExtendedChangePercent = "rchp"
+
+// FieldPremarketVolume ...
+const FieldPremarketVolume = "premarket_volume"
+
+// FieldPostmarketVolume ...
+const FieldPostmarketVolume = "postmarket_volume"
+
+// FieldCurrentSession - Trading session: pre_market, market, post_market or out_of_session
+const FieldCurrentSession = "current_session"
+
+// FieldUpdateMode - Realtime, delayed or end of day
+const FieldUpdateMode = "update_mode"
+
+// FieldIsTradable ...
+const FieldIsTradable = "is_tradable"
+
+// FieldIsHalted ...
+const FieldIsHalted = "is_halted"
+
+// FieldDelay ...
+const FieldDelay = "delay"
+
+// FieldMarketCap ...
+const FieldMarketCap = "market_cap_basic"
+
+// FieldPriceEarnings ...
+const FieldPriceEarnings = "price_earnings_ttm"
+
+// FieldEarningsPerShare ...
+const FieldEarningsPerShare = "earnings_per_share_basic_ttm"
+
+// FieldFloatShares ...
+const FieldFloatShares = "float_shares_outstanding"
+
+// FieldTotalShares ...
+const FieldTotalShares = "total_shares_outstanding"
+
+// FieldShortInterest ...
+const FieldShortInterest = "short_interest"
+
+// FieldDaysToCover - Short interest divided by the average daily volume
+const FieldDaysToCover = "days_to_cover"
+
+// FieldNextEarningsDate ...
+const FieldNextEarningsDate = "earnings_release_next_date"
+
+// FieldExDividendDate ...
+const FieldExDividendDate = "ex_dividend_date"
+
+// FieldDividendYield ...
+const FieldDividendYield = "dividends_yield"
+
+// FieldDescription ...
+const FieldDescription = "description"
+
+// FieldType ...
+const FieldType = "type"
+
+// FieldExchange ...
+const FieldExchange = "exchange"
+
+// FieldCurrency ...
+const FieldCurrency = "currency_code"
+
+// FieldSector ...
+const FieldSector = "sector"
+
+// FieldIndustry ...
+const FieldIndustry = "industry"
+
+// FieldCountry - ISO 3166 code of the country of the company
+const FieldCountry = "country_code"
+
+// FieldPriceScale ...
+const FieldPriceScale = "pricescale"
+
+// FieldMinMove ...
+const FieldMinMove = "minmov"
+
+// FieldMinMove2 ...
+const FieldMinMove2 = "minmove2"
+
+// FieldFractional ...
+const FieldFractional = "fractional"
+
+// FieldFrontContract - Contract a continuous futures symbol (ES1!...) follows
+const FieldFrontContract = "front_contract"
+
+// FieldsMinimal - Only the last price
+var FieldsMinimal = []string{FieldLastPrice}
+
+// FieldsTrading - What's usually needed to trade: prices, volume, change and the quality of the data
+var FieldsTrading = []string{
+	FieldLastPrice, FieldLastPriceTime, FieldVolume, FieldBid, FieldAsk, FieldBidSize, FieldAskSize,
+	FieldChange, FieldChangePercent, FieldCurrentSession, FieldUpdateMode,
+}
+
+// FieldsFull - All the known fields
+var FieldsFull = []string{
+	FieldLastPrice, FieldLastPriceTime, FieldVolume, FieldBid, FieldAsk, FieldBidSize, FieldAskSize,
+	FieldChange, FieldChangePercent, FieldOpenPrice, FieldHighPrice, FieldLowPrice, FieldPrevClosePrice, FieldAverageVolume,
+	FieldExtendedPrice, FieldExtendedPriceTime, FieldExtendedChange, FieldExtendedChangePercent, FieldPremarketVolume, FieldPostmarketVolume,
+	FieldCurrentSession, FieldUpdateMode, FieldDelay, FieldIsTradable, FieldIsHalted,
+	FieldMarketCap, FieldPriceEarnings, FieldEarningsPerShare, FieldNextEarningsDate, FieldExDividendDate, FieldDividendYield,
+	FieldFloatShares, FieldTotalShares, FieldShortInterest, FieldDaysToCover,
+	FieldDescription, FieldType, FieldExchange, FieldCurrency, FieldSector, FieldIndustry, FieldCountry, FieldPriceScale, FieldMinMove, FieldMinMove2, FieldFractional, FieldFrontContract,
+}
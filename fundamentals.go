@@ -0,0 +1,77 @@
+package tradingview
+
+import (
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+// FundamentalFields are the quote fields decoded into the Fundamentals of the quote data
+var FundamentalFields = []string{FieldMarketCap, FieldPriceEarnings, FieldEarningsPerShare}
+
+// ShareFields are the float and short interest quote fields, also decoded into the Fundamentals.
+// TradingView only provides them for some markets.
+var ShareFields = []string{FieldFloatShares, FieldTotalShares, FieldShortInterest, FieldDaysToCover}
+
+// CorporateEventFields are the earnings and dividend quote fields, also decoded into the Fundamentals
+var CorporateEventFields = []string{FieldNextEarningsDate, FieldExDividendDate, FieldDividendYield}
+
+// Fundamentals - Fundamental data of the symbol, only received when its fields are requested
+type Fundamentals struct {
+	MarketCap        *float64 `mapstructure:"market_cap_basic"`
+	PriceEarnings    *float64 `mapstructure:"price_earnings_ttm"`
+	EarningsPerShare *float64 `mapstructure:"earnings_per_share_basic_ttm"`
+
+	FloatShares   *float64 `mapstructure:"float_shares_outstanding"`
+	TotalShares   *float64 `mapstructure:"total_shares_outstanding"`
+	ShortInterest *float64 `mapstructure:"short_interest"`
+	DaysToCover   *float64 `mapstructure:"days_to_cover"`
+
+	// NextEarningsDate and ExDividendDate are unix times in seconds
+	NextEarningsDate *int64   `mapstructure:"earnings_release_next_date"`
+	ExDividendDate   *int64   `mapstructure:"ex_dividend_date"`
+	DividendYield    *float64 `mapstructure:"dividends_yield"`
+}
+
+// ShortPercentOfFloat returns the short interest as a percentage of the float, ok is false if any of them is unknown
+func (f *Fundamentals) ShortPercentOfFloat() (percent float64, ok bool) {
+	if f == nil || f.ShortInterest == nil || f.FloatShares == nil || *f.FloatShares == 0 {
+		return
+	}
+	return *f.ShortInterest / *f.FloatShares * 100, true
+}
+
+// NextEarningsAt returns the date of the next earnings release, or the zero time if it's unknown
+func (f *Fundamentals) NextEarningsAt() time.Time {
+	if f == nil || f.NextEarningsDate == nil {
+		return time.Time{}
+	}
+	return time.Unix(*f.NextEarningsDate, 0)
+}
+
+// ExDividendAt returns the ex-dividend date, or the zero time if it's unknown
+func (f *Fundamentals) ExDividendAt() time.Time {
+	if f == nil || f.ExDividendDate == nil {
+		return time.Time{}
+	}
+	return time.Unix(*f.ExDividendDate, 0)
+}
+
+// decodeFundamentals returns nil when the values don't include any fundamental, share or corporate event field
+func decodeFundamentals(values map[string]interface{}) (fundamentals *Fundamentals, err error) {
+	if !hasAnyField(values, FundamentalFields) && !hasAnyField(values, ShareFields) && !hasAnyField(values, CorporateEventFields) {
+		return
+	}
+
+	err = mapstructure.Decode(values, &fundamentals)
+	return
+}
+
+func hasAnyField(values map[string]interface{}, fields []string) bool {
+	for _, field := range fields {
+		if _, ok := values[field]; ok {
+			return true
+		}
+	}
+	return false
+}
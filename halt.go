@@ -0,0 +1,44 @@
+package tradingview
+
+import "time"
+
+// HaltFields are the quote fields used to detect when a symbol is halted or suspended
+var HaltFields = []string{FieldIsTradable, FieldIsHalted}
+
+// TradingHaltChanged - Event sent when a symbol is halted or suspended, and when it resumes trading
+type TradingHaltChanged struct {
+	Symbol string
+	Halted bool
+	At     time.Time
+}
+
+// setHalted remembers if the symbol is halted when the update includes it.
+// It returns whether it has changed; a symbol seen for the first time only counts as a change if it's halted.
+func (qs *QuoteSession) setHalted(symbol string, data *QuoteData) (halted bool, changed bool) {
+	if data.IsTradable == nil && data.IsHalted == nil {
+		return
+	}
+
+	halted = (data.IsHalted != nil && *data.IsHalted) || (data.IsTradable != nil && !*data.IsTradable)
+
+	qs.mutex.Lock()
+	defer qs.mutex.Unlock()
+
+	previous, ok := qs.halted[symbol]
+	qs.halted[symbol] = halted
+	changed = previous != halted || (!ok && halted)
+	return
+}
+
+// handleTradingHalt sends the event when the symbol is halted or resumes trading
+func (s *Socket) handleTradingHalt(session *QuoteSession, symbol string, data *QuoteData) {
+	if s.onTradingHalt == nil {
+		return
+	}
+
+	halted, changed := session.setHalted(symbol, data)
+	if !changed {
+		return
+	}
+	s.onTradingHalt(&TradingHaltChanged{Symbol: symbol, Halted: halted, At: data.ReceivedAt})
+}
@@ -0,0 +1,34 @@
+package tradingview
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// IsConnected tells if the connection is currently up and the quote session is ready
+func (s *Socket) IsConnected() bool {
+	return atomic.LoadInt32(&s.connected) == 1
+}
+
+// LastMessageAt returns when the last quote was received. It's the zero time if no quote has been received yet.
+func (s *Socket) LastMessageAt() time.Time {
+	lastQuoteAt := atomic.LoadInt64(&s.lastQuoteAt)
+	if lastQuoteAt == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(0, lastQuoteAt)
+}
+
+// ReconnectCount returns how many times the socket has reconnected successfully
+func (s *Socket) ReconnectCount() int64 {
+	return atomic.LoadInt64(&s.reconnectCount)
+}
+
+func (s *Socket) setConnected(connected bool) {
+	var value int32
+	if connected {
+		value = 1
+	}
+	atomic.StoreInt32(&s.connected, value)
+}
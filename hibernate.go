@@ -0,0 +1,76 @@
+package tradingview
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Pause sends quote_hibernate_all for all the quote sessions, so the server stops sending data
+// until Resume is called. The connection and the subscriptions are kept.
+func (s *Socket) Pause() (err error) {
+	atomic.StoreInt32(&s.paused, 1)
+	for _, qs := range s.getQuoteSessions() {
+		err = qs.Pause()
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// Resume makes all the quote sessions send data again after Pause
+func (s *Socket) Resume() (err error) {
+	// So the watchdog doesn't report the time paused as a stale connection
+	atomic.StoreInt64(&s.lastMessageAt, time.Now().UnixNano())
+	atomic.StoreInt32(&s.paused, 0)
+	for _, qs := range s.getQuoteSessions() {
+		err = qs.Resume()
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// IsPaused tells if the socket has been paused
+func (s *Socket) IsPaused() bool {
+	return atomic.LoadInt32(&s.paused) == 1
+}
+
+// Pause sends quote_hibernate_all, so the server stops sending the data of the session until Resume is called.
+// The session stays paused after a reconnection.
+func (qs *QuoteSession) Pause() (err error) {
+	qs.mutex.Lock()
+	qs.paused = true
+	qs.mutex.Unlock()
+
+	return qs.sendHibernate()
+}
+
+// Resume activates again the symbols of the session with quote_fast_symbols
+func (qs *QuoteSession) Resume() (err error) {
+	qs.mutex.Lock()
+	qs.paused = false
+	qs.mutex.Unlock()
+
+	symbols := qs.getSymbols()
+	if len(symbols) == 0 {
+		return
+	}
+
+	err = qs.socket.sendSocketMessage(
+		getSocketMessage("quote_fast_symbols", append([]string{qs.SessionID()}, symbols...)),
+	)
+	return
+}
+
+func (qs *QuoteSession) isPaused() bool {
+	qs.mutex.RLock()
+	defer qs.mutex.RUnlock()
+
+	return qs.paused
+}
+
+func (qs *QuoteSession) sendHibernate() error {
+	return qs.socket.sendSocketMessage(getSocketMessage("quote_hibernate_all", []string{qs.SessionID()}))
+}
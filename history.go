@@ -0,0 +1,259 @@
+package tradingview
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// maxHistoryBars is the number of bars requested by GetHistoryRange, the server stops at the start of the range
+const maxHistoryBars = 20000
+
+// historyPageSize is the number of bars GetHistory requests at once, the server doesn't send more in a single page
+const historyPageSize = 5000
+
+// DefaultHistoryRequestInterval is the wait between the pages of GetHistory
+const DefaultHistoryRequestInterval = 500 * time.Millisecond
+
+// GetHistory returns the last barCount candles of the symbol in the given timeframe, oldest first.
+// It opens a chart session on the connection of the socket, waits for the history and closes it.
+// The long histories are requested in pages of 5000 candles, see WithHistoryProgress and WithHistoryRequestInterval.
+// There can be fewer candles than requested when the symbol doesn't have more.
+func (s *Socket) GetHistory(
+	ctx context.Context,
+	symbol string,
+	timeframe Timeframe,
+	barCount int,
+	options ...ChartOption,
+) (candles []*Candle, err error) {
+	if symbol == "" {
+		return nil, errors.New("the symbol can't be empty")
+	}
+	err = timeframe.Validate()
+	if err != nil {
+		return
+	}
+	if barCount <= 0 {
+		return nil, errors.New("the number of bars must be positive")
+	}
+
+	pageSize := barCount
+	if pageSize > historyPageSize {
+		pageSize = historyPageSize
+	}
+	cs, err := s.newHistoryChartSession(symbol, timeframe, pageSize, options...)
+	if err != nil {
+		return
+	}
+
+	return s.getCachedHistory(cs, barCount, time.Time{}, func() (candles []*Candle, err error) {
+		candles, err = s.getHistory(ctx, cs, barCount, time.Time{})
+		if err != nil {
+			return
+		}
+
+		candles = s.handleHistoryGaps(ctx, cs, candles, options)
+		if len(candles) > barCount {
+			candles = candles[len(candles)-barCount:]
+		}
+		return
+	})
+}
+
+// GetHistoryRange returns the candles of the symbol in the given timeframe between from and to, oldest first.
+// The long ranges are requested in pages, as GetHistory does.
+func (s *Socket) GetHistoryRange(
+	ctx context.Context,
+	symbol string,
+	timeframe Timeframe,
+	from time.Time,
+	to time.Time,
+	options ...ChartOption,
+) (candles []*Candle, err error) {
+	if symbol == "" {
+		return nil, errors.New("the symbol can't be empty")
+	}
+	err = timeframe.Validate()
+	if err != nil {
+		return
+	}
+	if !from.Before(to) {
+		return nil, errors.New("the start of the range must be before its end")
+	}
+
+	cs, err := s.newHistoryChartSession(symbol, timeframe, maxHistoryBars, options...)
+	if err != nil {
+		return
+	}
+	cs.barsRange = "r," + strconv.FormatInt(from.Unix(), 10) + ":" + strconv.FormatInt(to.Unix(), 10)
+
+	return s.getCachedHistory(cs, 0, to, func() (candles []*Candle, err error) {
+		all, err := s.getHistory(ctx, cs, 0, from)
+		if err == nil {
+			all = s.handleHistoryGaps(ctx, cs, all, options)
+		}
+		for _, candle := range all {
+			if !candle.Time.Before(from) && !candle.Time.After(to) {
+				candles = append(candles, candle)
+			}
+		}
+		return
+	})
+}
+
+// newHistoryChartSession creates a chart session that collects the candles by time,
+// so the updates of the same bar received while loading replace the previous ones
+func (s *Socket) newHistoryChartSession(
+	symbol string,
+	timeframe Timeframe,
+	barCount int,
+	options ...ChartOption,
+) (cs *ChartSession, err error) {
+	var mutex sync.Mutex
+	candles := map[int64]*Candle{}
+
+	cs, err = s.newChartSession(symbol, timeframe, barCount, func(symbol string, candle *Candle) {
+		mutex.Lock()
+		candles[candle.Time.Unix()] = candle
+		mutex.Unlock()
+	}, options...)
+	if err != nil {
+		return
+	}
+	cs.collected = func() []*Candle {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		return sortCandles(candles)
+	}
+	return
+}
+
+// sortCandles returns the candles collected by time, oldest first
+func sortCandles(candles map[int64]*Candle) []*Candle {
+	sorted := make([]*Candle, 0, len(candles))
+	for _, candle := range candles {
+		sorted = append(sorted, candle)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Time.Before(sorted[j].Time) })
+	return sorted
+}
+
+// getHistory creates the chart session and waits for its history, requesting more pages until there are barCount candles,
+// or until the first one is at or before from when it's set, or until the server doesn't send more.
+// With barCount 0 and no from only the first page is loaded.
+func (s *Socket) getHistory(
+	ctx context.Context,
+	cs *ChartSession,
+	barCount int,
+	from time.Time,
+) (candles []*Candle, err error) {
+	err = s.createChartSession(cs)
+	if err != nil {
+		return
+	}
+	defer cs.Close()
+
+	err = s.waitForHistory(ctx, cs, cs.loaded)
+	if err != nil {
+		return
+	}
+	// The first page is already loaded
+	select {
+	case <-cs.completed:
+	default:
+	}
+
+	interval := cs.requestInterval
+	if interval == 0 {
+		interval = DefaultHistoryRequestInterval
+	}
+
+	candles = cs.collected()
+	for {
+		if cs.onProgress != nil && barCount > 0 {
+			cs.onProgress(cs.symbol, len(candles), barCount)
+		}
+		if isHistoryLoaded(candles, barCount, from) {
+			return
+		}
+
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return candles, ctx.Err()
+		}
+
+		count := historyPageSize
+		if barCount > 0 && barCount-len(candles) < count {
+			count = barCount - len(candles)
+		}
+		err = s.sendSocketMessage(
+			getSocketMessage("request_more_data", []interface{}{cs.SessionID(), cs.seriesID(), count}),
+		)
+		if err != nil {
+			return
+		}
+
+		err = s.waitForHistory(ctx, cs, cs.completed)
+		if err != nil {
+			return
+		}
+
+		previous := len(candles)
+		candles = cs.collected()
+		// The start of the history of the symbol
+		if len(candles) == previous {
+			return
+		}
+	}
+}
+
+// isHistoryLoaded tells if the candles reach the start of the range when there's one, or if there are barCount of them.
+// A range without candles in its first page has none.
+func isHistoryLoaded(candles []*Candle, barCount int, from time.Time) bool {
+	if !from.IsZero() {
+		return len(candles) == 0 || !candles[0].Time.After(from)
+	}
+	return len(candles) >= barCount
+}
+
+// waitForHistory waits for the channel, returning the errors of the chart session and the cancellations meanwhile
+func (s *Socket) waitForHistory(ctx context.Context, cs *ChartSession, ready <-chan struct{}) (err error) {
+	select {
+	case <-ready:
+	case err = <-cs.failed:
+	case <-ctx.Done():
+		err = ctx.Err()
+	case <-s.ctx.Done():
+		err = errors.New("the socket was closed before the history was received")
+	}
+	return
+}
+
+// WithHistoryProgress calls the callback after every page of GetHistory, with the candles loaded so far and the ones requested
+func WithHistoryProgress(onProgress OnHistoryProgressCallback) ChartOption {
+	return func(cs *ChartSession) error {
+		if onProgress == nil {
+			return errors.New("the progress callback can't be nil")
+		}
+
+		cs.onProgress = onProgress
+		return nil
+	}
+}
+
+// WithHistoryRequestInterval sets the wait between the pages of GetHistory, 500ms by default, to avoid being throttled
+func WithHistoryRequestInterval(interval time.Duration) ChartOption {
+	return func(cs *ChartSession) error {
+		if interval <= 0 {
+			return errors.New("the interval between the history requests must be positive")
+		}
+
+		cs.requestInterval = interval
+		return nil
+	}
+}
@@ -0,0 +1,90 @@
+package tradingview
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// HTTPStatusError is returned by the HTTP helpers when TradingView answers with an unexpected status code
+type HTTPStatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *HTTPStatusError) Error() string {
+	return "unexpected HTTP status " + strconv.Itoa(e.StatusCode) + " -> " + e.Body
+}
+
+// newHTTPRequest creates a request with the same browser-like headers the website sends
+func newHTTPRequest(ctx context.Context, method string, url string, body io.Reader) (req *http.Request, err error) {
+	if ctx == nil {
+		return nil, errors.New("the context can't be nil")
+	}
+
+	req, err = http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return
+	}
+
+	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
+	req.Header.Set("Origin", "https://www.tradingview.com")
+	req.Header.Set("Referer", "https://www.tradingview.com/")
+	req.Header.Set("User-Agent", DefaultUserAgent)
+	return
+}
+
+// doHTTPRequest sends the request and decodes the JSON response into v, which can be nil to ignore the body.
+// Any status code other than 2xx is returned as an *HTTPStatusError.
+func doHTTPRequest(client *http.Client, req *http.Request, v interface{}) (res *http.Response, err error) {
+	res, body, err := readHTTPResponse(client, req)
+	if err != nil {
+		return
+	}
+
+	if v != nil {
+		err = json.Unmarshal(body, v)
+	}
+	return
+}
+
+// readHTTPResponse sends the request and returns the raw body
+func readHTTPResponse(client *http.Client, req *http.Request) (res *http.Response, body []byte, err error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	res, err = client.Do(req)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+
+	body, err = io.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		err = &HTTPStatusError{StatusCode: res.StatusCode, Body: string(body)}
+	}
+	return
+}
+
+// getHTTPClient returns the client of the HTTP requests the socket sends on its own (the watchlists...),
+// through the proxy of WithProxy if there's one. nil means http.DefaultClient.
+func (s *Socket) getHTTPClient() *http.Client {
+	if s.proxyURL == nil {
+		return nil
+	}
+
+	s.httpClientOnce.Do(func() {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.Proxy = http.ProxyURL(s.proxyURL)
+		s.httpClient = &http.Client{Transport: transport}
+	})
+	return s.httpClient
+}
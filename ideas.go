@@ -0,0 +1,132 @@
+package tradingview
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// IdeaDirection - Trade the author of an idea expects
+type IdeaDirection string
+
+// IdeaLong ...
+const IdeaLong IdeaDirection = "long"
+
+// IdeaShort ...
+const IdeaShort IdeaDirection = "short"
+
+// IdeaNeutral - The idea is not a trade, or the author didn't say
+const IdeaNeutral IdeaDirection = ""
+
+// Idea - Analysis published by a user of the website about a symbol
+type Idea struct {
+	ID          int64
+	Title       string
+	Description string
+	Author      string
+	Direction   IdeaDirection
+	Published   time.Time
+	// URL is the page of the idea, and ImageURL the snapshot of its chart
+	URL      string
+	ImageURL string
+	Likes    int
+	Comments int
+}
+
+// IdeasPage - Page of the ideas of a symbol, the most recent first
+type IdeasPage struct {
+	Ideas []*Idea
+	// Page starts at 1, and HasNext tells if there are older ideas in the next one
+	Page    int
+	HasNext bool
+}
+
+type ideasResponse struct {
+	Ideas struct {
+		Data struct {
+			Items []*ideaItem `json:"items"`
+			Next  string      `json:"next"`
+		} `json:"data"`
+	} `json:"ideas"`
+}
+
+type ideaItem struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	CreatedAt   string `json:"created_at"`
+	ChartURL    string `json:"chart_url"`
+	Strategy    string `json:"strategy"`
+	Likes       int    `json:"likes_count"`
+	Comments    int    `json:"comments_count"`
+	Image       struct {
+		Big string `json:"big"`
+	} `json:"image"`
+	User struct {
+		Username string `json:"username"`
+	} `json:"user"`
+}
+
+// GetIdeas returns a page of the ideas published for the symbol (NASDAQ:AAPL), the most recent first.
+// The pages start at 1. The HTTP client can be nil to use http.DefaultClient.
+func GetIdeas(ctx context.Context, client *http.Client, symbol string, page int) (ideas *IdeasPage, err error) {
+	parsed, err := ParseSymbol(symbol)
+	if err != nil {
+		return
+	}
+	if page <= 0 {
+		return nil, errors.New("the pages of the ideas start at 1")
+	}
+
+	path := HomeURL + "symbols/" + url.PathEscape(parsed.Exchange+"-"+parsed.Ticker) + "/ideas/"
+	if page > 1 {
+		path += "page-" + strconv.Itoa(page) + "/"
+	}
+	query := url.Values{}
+	query.Set("component-data-only", "1")
+	query.Set("sort", "recent")
+
+	req, err := newHTTPRequest(ctx, http.MethodGet, path+"?"+query.Encode(), nil)
+	if err != nil {
+		return
+	}
+
+	var res *ideasResponse
+	_, err = doHTTPRequest(client, req, &res)
+	if err != nil {
+		return
+	}
+	if res == nil {
+		return nil, errors.New("empty response from the ideas of " + symbol)
+	}
+
+	ideas = &IdeasPage{Page: page, HasNext: res.Ideas.Data.Next != ""}
+	for _, item := range res.Ideas.Data.Items {
+		idea := &Idea{
+			ID:          item.ID,
+			Title:       item.Name,
+			Description: item.Description,
+			Author:      item.User.Username,
+			URL:         item.ChartURL,
+			ImageURL:    item.Image.Big,
+			Likes:       item.Likes,
+			Comments:    item.Comments,
+		}
+		if strings.HasPrefix(idea.URL, "/") {
+			idea.URL = HomeURL + strings.TrimPrefix(idea.URL, "/")
+		}
+		idea.Published, _ = time.Parse(time.RFC3339, item.CreatedAt)
+		switch strings.ToLower(item.Strategy) {
+		case "long":
+			idea.Direction = IdeaLong
+		case "short":
+			idea.Direction = IdeaShort
+		}
+		ideas.Ideas = append(ideas.Ideas, idea)
+	}
+	return
+}
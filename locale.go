@@ -0,0 +1,16 @@
+package tradingview
+
+// Locale returns the language and country set with WithLocale.
+// They're sent again on every reconnection.
+func (s *Socket) Locale() (language string, country string) {
+	return s.language, s.country
+}
+
+// sendLocale sends set_locale, so the descriptions of the symbols and the error messages
+// arrive in the language of the user. Nothing is sent if no locale has been set.
+func (s *Socket) sendLocale() error {
+	if s.language == "" {
+		return nil
+	}
+	return s.sendSocketMessage(getSocketMessage("set_locale", []string{s.language, s.country}))
+}
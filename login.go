@@ -0,0 +1,180 @@
+package tradingview
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"time"
+)
+
+// SignInURL ...
+const SignInURL = "https://www.tradingview.com/accounts/signin/"
+
+// TwoFactorSignInURL ...
+const TwoFactorSignInURL = "https://www.tradingview.com/accounts/two-factor/signin/totp/"
+
+// ErrTwoFactorRequired is returned by Login when the account has two-factor authentication enabled,
+// use LoginWithTwoFactorCode or LoginWithTOTPSecret instead
+var ErrTwoFactorRequired = errors.New("the account requires two-factor authentication")
+
+// LoginError - TradingView rejected the sign in, e.g. because of wrong credentials or a captcha being required
+type LoginError struct {
+	Code    string
+	Message string
+}
+
+func (e *LoginError) Error() string {
+	return "could not sign in (" + e.Code + ") -> " + e.Message
+}
+
+// Credentials - Result of signing in. AuthToken is the one needed by WithAuthToken / SetAuthToken,
+// SessionID and SessionIDSign are the values of the session cookies, that can be exchanged later for a new token with GetAuthTokenFromSession.
+type Credentials struct {
+	Username      string
+	AuthToken     string
+	SessionID     string
+	SessionIDSign string
+}
+
+type signInResponse struct {
+	Error string `json:"error"`
+	Code  string `json:"code"`
+	User  *struct {
+		Username  string `json:"username"`
+		AuthToken string `json:"auth_token"`
+	} `json:"user"`
+}
+
+// Login signs in to TradingView with a username (or email) and password and returns the credentials
+func Login(username string, password string) (*Credentials, error) {
+	return LoginWithContext(context.Background(), nil, username, password)
+}
+
+// LoginWithContext - Same as Login, with a context and a custom HTTP client (http.DefaultClient if nil)
+func LoginWithContext(ctx context.Context, client *http.Client, username string, password string) (credentials *Credentials, err error) {
+	response, cookies, err := signIn(ctx, client, username, password)
+	if err != nil {
+		return
+	}
+
+	return getCredentials(response, cookies)
+}
+
+// LoginWithTwoFactorCode signs in to an account with two-factor authentication enabled, using the current one-time code
+func LoginWithTwoFactorCode(ctx context.Context, client *http.Client, username string, password string, code string) (credentials *Credentials, err error) {
+	return loginWithTwoFactor(ctx, client, username, password, func() (string, error) {
+		return code, nil
+	})
+}
+
+// LoginWithTOTPSecret signs in to an account with two-factor authentication enabled, generating the one-time code
+// from the TOTP secret (the one shown, usually as a QR code, when enabling 2FA)
+func LoginWithTOTPSecret(ctx context.Context, client *http.Client, username string, password string, secret string) (credentials *Credentials, err error) {
+	return loginWithTwoFactor(ctx, client, username, password, func() (string, error) {
+		return GenerateTOTP(secret, time.Now())
+	})
+}
+
+// loginWithTwoFactor signs in with the password and, if the account asks for it, sends the one-time code
+// along with the cookies of the first step. The code is generated as late as possible, so it doesn't expire in between.
+func loginWithTwoFactor(
+	ctx context.Context,
+	client *http.Client,
+	username string,
+	password string,
+	getCode func() (string, error),
+) (credentials *Credentials, err error) {
+	response, cookies, err := signIn(ctx, client, username, password)
+	if err != nil {
+		return
+	}
+
+	credentials, err = getCredentials(response, cookies)
+	if err != ErrTwoFactorRequired {
+		return
+	}
+
+	code, err := getCode()
+	if err != nil {
+		return
+	}
+
+	response, twoFactorCookies, err := postSignInForm(ctx, client, TwoFactorSignInURL, map[string]string{
+		"code": code,
+	}, cookies)
+	if err != nil {
+		return
+	}
+
+	return getCredentials(response, append(cookies, twoFactorCookies...))
+}
+
+func signIn(ctx context.Context, client *http.Client, username string, password string) (*signInResponse, []*http.Cookie, error) {
+	return postSignInForm(ctx, client, SignInURL, map[string]string{
+		"username": username,
+		"password": password,
+		"remember": "on",
+	}, nil)
+}
+
+func postSignInForm(
+	ctx context.Context,
+	client *http.Client,
+	url string,
+	fields map[string]string,
+	cookies []*http.Cookie,
+) (response *signInResponse, responseCookies []*http.Cookie, err error) {
+	body := &bytes.Buffer{}
+	form := multipart.NewWriter(body)
+	for name, value := range fields {
+		form.WriteField(name, value)
+	}
+	err = form.Close()
+	if err != nil {
+		return
+	}
+
+	req, err := newHTTPRequest(ctx, http.MethodPost, url, body)
+	if err != nil {
+		return
+	}
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	for _, cookie := range cookies {
+		req.AddCookie(&http.Cookie{Name: cookie.Name, Value: cookie.Value})
+	}
+
+	res, err := doHTTPRequest(client, req, &response)
+	if err != nil {
+		return
+	}
+
+	return response, res.Cookies(), nil
+}
+
+func getCredentials(response *signInResponse, cookies []*http.Cookie) (credentials *Credentials, err error) {
+	if response == nil {
+		return nil, &LoginError{Message: "empty response"}
+	}
+	if response.Code == "2FA_required" || response.Error == "2FA_required" {
+		return nil, ErrTwoFactorRequired
+	}
+	if response.Error != "" || response.User == nil || response.User.AuthToken == "" {
+		return nil, &LoginError{Code: response.Code, Message: response.Error}
+	}
+
+	credentials = &Credentials{
+		Username:  response.User.Username,
+		AuthToken: response.User.AuthToken,
+	}
+	for _, cookie := range cookies {
+		switch cookie.Name {
+		case "sessionid":
+			credentials.SessionID = cookie.Value
+		case "sessionid_sign":
+			credentials.SessionIDSign = cookie.Value
+		}
+	}
+	return
+}
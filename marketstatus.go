@@ -0,0 +1,70 @@
+package tradingview
+
+import "time"
+
+// MarketStatus - Status of the market of a symbol
+type MarketStatus string
+
+// MarketStatusOpen ...
+const MarketStatusOpen MarketStatus = "open"
+
+// MarketStatusClosed ...
+const MarketStatusClosed MarketStatus = "closed"
+
+// MarketStatusPreMarket ...
+const MarketStatusPreMarket MarketStatus = "pre"
+
+// MarketStatusPostMarket ...
+const MarketStatusPostMarket MarketStatus = "post"
+
+// MarketStatusHoliday ...
+const MarketStatusHoliday MarketStatus = "holiday"
+
+// MarketStatusUnknown is the status of a symbol before its session has been received
+const MarketStatusUnknown MarketStatus = ""
+
+// MarketStatusChanged - Event sent when the market of a symbol opens, closes, or enters the pre or post market
+type MarketStatusChanged struct {
+	Symbol   string
+	Previous MarketStatus
+	Current  MarketStatus
+	At       time.Time
+}
+
+// getMarketStatus converts the trading session of the current_session field into a MarketStatus
+func getMarketStatus(session TradingSession) MarketStatus {
+	switch session {
+	case TradingSessionMarket:
+		return MarketStatusOpen
+	case TradingSessionPreMarket:
+		return MarketStatusPreMarket
+	case TradingSessionPostMarket:
+		return MarketStatusPostMarket
+	case TradingSessionOutOfSession:
+		return MarketStatusClosed
+	case "holiday":
+		return MarketStatusHoliday
+	case "":
+		return MarketStatusUnknown
+	default:
+		return MarketStatusClosed
+	}
+}
+
+// handleTradingSessionChange sends the market status event when the session of the symbol changes
+func (s *Socket) handleTradingSessionChange(symbol string, previous TradingSession, data *QuoteData) {
+	if s.onMarketStatus == nil {
+		return
+	}
+
+	event := &MarketStatusChanged{
+		Symbol:   symbol,
+		Previous: getMarketStatus(previous),
+		Current:  getMarketStatus(data.TradingSession),
+		At:       data.ReceivedAt,
+	}
+	if event.Previous == event.Current {
+		return
+	}
+	s.onMarketStatus(event)
+}
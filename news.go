@@ -0,0 +1,174 @@
+package tradingview
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// NewsHeadlinesURL ...
+const NewsHeadlinesURL = "https://news-headlines.tradingview.com/v2/headlines"
+
+// NewsCategory - Market of the news of the website
+type NewsCategory string
+
+// NewsCategoryAll ...
+const NewsCategoryAll NewsCategory = "base"
+
+// NewsCategoryStocks ...
+const NewsCategoryStocks NewsCategory = "stock"
+
+// NewsCategoryCrypto ...
+const NewsCategoryCrypto NewsCategory = "crypto"
+
+// NewsCategoryForex ...
+const NewsCategoryForex NewsCategory = "forex"
+
+// NewsCategoryIndices ...
+const NewsCategoryIndices NewsCategory = "index"
+
+// NewsCategoryFutures ...
+const NewsCategoryFutures NewsCategory = "futures"
+
+// NewsCategoryBonds ...
+const NewsCategoryBonds NewsCategory = "bond"
+
+// NewsCategoryEconomy ...
+const NewsCategoryEconomy NewsCategory = "economic"
+
+// NewsHeadline - Headline of a news story
+type NewsHeadline struct {
+	ID        string
+	Title     string
+	Provider  string
+	Source    string
+	Published time.Time
+	// Urgency is 1 for the breaking news, 2 for the rest
+	Urgency int
+	// Link is the original story, and StoryURL its page on the website
+	Link     string
+	StoryURL string
+	// Symbols are the symbols the story is about, with their exchange
+	Symbols []string
+}
+
+type newsHeadlinesResponse struct {
+	Items []*newsItem `json:"items"`
+}
+
+type newsItem struct {
+	ID             string `json:"id"`
+	Title          string `json:"title"`
+	Provider       string `json:"provider"`
+	Source         string `json:"source"`
+	Published      int64  `json:"published"`
+	Urgency        int    `json:"urgency"`
+	Link           string `json:"link"`
+	StoryPath      string `json:"storyPath"`
+	RelatedSymbols []struct {
+		Symbol string `json:"symbol"`
+	} `json:"relatedSymbols"`
+}
+
+// GetNewsHeadlines returns the latest headlines of the symbol (NASDAQ:AAPL), or of the category when the symbol is empty,
+// newest first. The HTTP client can be nil to use http.DefaultClient.
+func GetNewsHeadlines(
+	ctx context.Context,
+	client *http.Client,
+	symbol string,
+	category NewsCategory,
+) (headlines []*NewsHeadline, err error) {
+	if symbol == "" && category == "" {
+		return nil, errors.New("the symbol or the category of the news must be given")
+	}
+
+	query := url.Values{}
+	query.Set("client", "web")
+	query.Set("lang", "en")
+	if symbol != "" {
+		query.Set("symbol", symbol)
+	}
+	if category != "" {
+		query.Set("category", string(category))
+	}
+
+	req, err := newHTTPRequest(ctx, http.MethodGet, NewsHeadlinesURL+"?"+query.Encode(), nil)
+	if err != nil {
+		return
+	}
+
+	var res *newsHeadlinesResponse
+	_, err = doHTTPRequest(client, req, &res)
+	if err != nil || res == nil {
+		return
+	}
+
+	for _, item := range res.Items {
+		headline := &NewsHeadline{
+			ID:        item.ID,
+			Title:     item.Title,
+			Provider:  item.Provider,
+			Source:    item.Source,
+			Published: time.Unix(item.Published, 0),
+			Urgency:   item.Urgency,
+			Link:      item.Link,
+		}
+		if item.StoryPath != "" {
+			headline.StoryURL = HomeURL + strings.TrimPrefix(item.StoryPath, "/")
+		}
+		for _, related := range item.RelatedSymbols {
+			headline.Symbols = append(headline.Symbols, related.Symbol)
+		}
+		headlines = append(headlines, headline)
+	}
+	return
+}
+
+// WatchNews requests the headlines of the symbol or the category every interval, and calls onHeadline with the new ones,
+// oldest first, until the context is done. The headlines published before the first request are not delivered.
+// The errors of the requests are sent to onError, and it keeps trying.
+func WatchNews(
+	ctx context.Context,
+	client *http.Client,
+	symbol string,
+	category NewsCategory,
+	interval time.Duration,
+	onHeadline OnNewsHeadlineCallback,
+	onError OnErrorCallback,
+) (err error) {
+	if onHeadline == nil || onError == nil {
+		return errors.New("the callbacks of the news can't be nil")
+	}
+	if interval <= 0 {
+		return errors.New("the interval of the news must be positive")
+	}
+
+	// seen are the ids of the headlines of the last response, the older ones don't come back
+	var seen map[string]bool
+	for {
+		var headlines []*NewsHeadline
+		headlines, err = GetNewsHeadlines(ctx, client, symbol, category)
+		if err != nil && ctx.Err() == nil {
+			onError(err, NewsErrorContext)
+		}
+		if err == nil {
+			latest := map[string]bool{}
+			for i := len(headlines) - 1; i >= 0; i-- {
+				latest[headlines[i].ID] = true
+				if seen != nil && !seen[headlines[i].ID] {
+					onHeadline(headlines[i])
+				}
+			}
+			seen = latest
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
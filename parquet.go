@@ -0,0 +1,475 @@
+package tradingview
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"sync"
+	"time"
+)
+
+// parquetMagic starts and ends the Parquet files
+const parquetMagic = "PAR1"
+
+// DefaultParquetRowGroupSize is the number of rows buffered before they're written as a row group
+const DefaultParquetRowGroupSize = 100000
+
+// Parquet physical types, repetition types, converted types and encodings, as defined by the format
+const (
+	parquetTypeInt64     = 2
+	parquetTypeDouble    = 5
+	parquetTypeByteArray = 6
+
+	parquetRepetitionRequired = 0
+	parquetRepetitionOptional = 1
+
+	parquetConvertedNone            = -1
+	parquetConvertedUTF8            = 0
+	parquetConvertedTimestampMillis = 9
+
+	parquetEncodingPlain = 0
+	parquetEncodingRLE   = 3
+)
+
+// Thrift compact protocol types, the encoding of the Parquet metadata
+const (
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftBinary = 8
+	thriftList   = 9
+	thriftStruct = 12
+)
+
+// CandleParquetWriter writes candles as a Parquet file, with the time as a timestamp in milliseconds and the prices
+// and the volume as doubles, ready to be loaded by pandas or DuckDB. The file is only valid after Close.
+type CandleParquetWriter struct {
+	file *parquetFile
+}
+
+// NewCandleParquetWriter creates a writer that buffers rowGroupSize candles before writing them,
+// DefaultParquetRowGroupSize if 0
+func NewCandleParquetWriter(w io.Writer, rowGroupSize int) (writer *CandleParquetWriter, err error) {
+	file, err := newParquetFile(w, rowGroupSize, []*parquetColumn{
+		{name: "time", physicalType: parquetTypeInt64, convertedType: parquetConvertedTimestampMillis},
+		{name: "open", physicalType: parquetTypeDouble, convertedType: parquetConvertedNone},
+		{name: "high", physicalType: parquetTypeDouble, convertedType: parquetConvertedNone},
+		{name: "low", physicalType: parquetTypeDouble, convertedType: parquetConvertedNone},
+		{name: "close", physicalType: parquetTypeDouble, convertedType: parquetConvertedNone},
+		{name: "volume", physicalType: parquetTypeDouble, convertedType: parquetConvertedNone},
+	})
+	if err != nil {
+		return
+	}
+
+	writer = &CandleParquetWriter{file: file}
+	return
+}
+
+// WriteCandles adds the candles to the file
+func (w *CandleParquetWriter) WriteCandles(candles []*Candle) (err error) {
+	for _, candle := range candles {
+		err = w.file.writeRow(
+			getUnixMilli(candle.Time), candle.Open, candle.High, candle.Low, candle.Close, candle.Volume,
+		)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// Close writes the buffered candles and the metadata of the file. It doesn't close the underlying writer.
+func (w *CandleParquetWriter) Close() error {
+	return w.file.close()
+}
+
+// TickParquetWriter writes the quotes received by the callbacks as a Parquet file, one row per update with
+// the time it was received, the symbol, and the last price, volume, bid and ask. The values not sent are null.
+type TickParquetWriter struct {
+	file *parquetFile
+}
+
+// NewTickParquetWriter creates a writer that buffers rowGroupSize ticks before writing them,
+// DefaultParquetRowGroupSize if 0
+func NewTickParquetWriter(w io.Writer, rowGroupSize int) (writer *TickParquetWriter, err error) {
+	file, err := newParquetFile(w, rowGroupSize, []*parquetColumn{
+		{name: "time", physicalType: parquetTypeInt64, convertedType: parquetConvertedTimestampMillis},
+		{name: "symbol", physicalType: parquetTypeByteArray, convertedType: parquetConvertedUTF8},
+		{name: "price", physicalType: parquetTypeDouble, convertedType: parquetConvertedNone, optional: true},
+		{name: "volume", physicalType: parquetTypeDouble, convertedType: parquetConvertedNone, optional: true},
+		{name: "bid", physicalType: parquetTypeDouble, convertedType: parquetConvertedNone, optional: true},
+		{name: "ask", physicalType: parquetTypeDouble, convertedType: parquetConvertedNone, optional: true},
+	})
+	if err != nil {
+		return
+	}
+
+	writer = &TickParquetWriter{file: file}
+	return
+}
+
+// WriteTick adds the update of the symbol to the file, it can be called from the data callback
+func (w *TickParquetWriter) WriteTick(symbol string, data *QuoteData) error {
+	receivedAt := data.ReceivedAt
+	if receivedAt.IsZero() {
+		receivedAt = time.Now()
+	}
+
+	return w.file.writeRow(getUnixMilli(receivedAt), symbol, data.Price, data.Volume, data.Bid, data.Ask)
+}
+
+// Close writes the buffered ticks and the metadata of the file. It doesn't close the underlying writer.
+func (w *TickParquetWriter) Close() error {
+	return w.file.close()
+}
+
+func getUnixMilli(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+// parquetFile writes a Parquet file of flat columns, with a single uncompressed plain page per column and row group
+type parquetFile struct {
+	w            io.Writer
+	offset       int64
+	columns      []*parquetColumn
+	rowGroupSize int
+	rows         int
+	rowGroups    []*parquetRowGroup
+	closed       bool
+	mutex        sync.Mutex
+}
+
+type parquetColumn struct {
+	name          string
+	physicalType  int32
+	convertedType int32
+	// optional columns can have null values, given as nil pointers
+	optional bool
+	// values has the plain encoded values of the row group being buffered, without the nulls
+	values bytes.Buffer
+	// defined has the definition level of every row of the optional columns, false for the nulls
+	defined []bool
+}
+
+type parquetRowGroup struct {
+	rows   int
+	size   int64
+	chunks []*parquetColumnChunk
+}
+
+type parquetColumnChunk struct {
+	offset int64
+	size   int64
+}
+
+func newParquetFile(w io.Writer, rowGroupSize int, columns []*parquetColumn) (file *parquetFile, err error) {
+	if w == nil {
+		return nil, errors.New("the writer can't be nil")
+	}
+	if rowGroupSize < 0 {
+		return nil, errors.New("the size of the row groups can't be negative")
+	}
+	if rowGroupSize == 0 {
+		rowGroupSize = DefaultParquetRowGroupSize
+	}
+
+	file = &parquetFile{w: w, columns: columns, rowGroupSize: rowGroupSize}
+	err = file.write([]byte(parquetMagic))
+	return
+}
+
+// writeRow buffers a row, the values must have the types of the columns: int64, float64 or string,
+// or *float64 for the optional ones
+func (f *parquetFile) writeRow(values ...interface{}) (err error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.closed {
+		return errors.New("the Parquet file is closed")
+	}
+
+	for i, value := range values {
+		buffer := &f.columns[i].values
+		switch v := value.(type) {
+		case int64:
+			err = binary.Write(buffer, binary.LittleEndian, v)
+		case float64:
+			err = binary.Write(buffer, binary.LittleEndian, v)
+		case *float64:
+			f.columns[i].defined = append(f.columns[i].defined, v != nil)
+			if v != nil {
+				err = binary.Write(buffer, binary.LittleEndian, *v)
+			}
+		case string:
+			err = binary.Write(buffer, binary.LittleEndian, uint32(len(v)))
+			buffer.WriteString(v)
+		}
+		if err != nil {
+			return
+		}
+	}
+
+	f.rows++
+	if f.rows >= f.rowGroupSize {
+		err = f.flush()
+	}
+	return
+}
+
+// flush writes the buffered rows as a row group
+func (f *parquetFile) flush() (err error) {
+	if f.rows == 0 {
+		return
+	}
+
+	rowGroup := &parquetRowGroup{rows: f.rows}
+	for _, column := range f.columns {
+		var levels []byte
+		if column.optional {
+			levels = encodeDefinitionLevels(column.defined)
+		}
+
+		header := &thriftWriter{}
+		header.writeI32(1, 0) // Data page
+		header.writeI32(2, int32(len(levels)+column.values.Len()))
+		header.writeI32(3, int32(len(levels)+column.values.Len()))
+		header.beginStruct(5)
+		header.writeI32(1, int32(f.rows))
+		header.writeI32(2, parquetEncodingPlain)
+		header.writeI32(3, parquetEncodingRLE)
+		header.writeI32(4, parquetEncodingRLE)
+		header.endStruct()
+		header.endStruct()
+
+		size := int64(header.buffer.Len() + len(levels) + column.values.Len())
+		chunk := &parquetColumnChunk{offset: f.offset, size: size}
+		err = f.write(header.buffer.Bytes())
+		if err != nil {
+			return
+		}
+		err = f.write(levels)
+		if err != nil {
+			return
+		}
+		err = f.write(column.values.Bytes())
+		if err != nil {
+			return
+		}
+		column.values.Reset()
+		column.defined = column.defined[:0]
+
+		rowGroup.size += chunk.size
+		rowGroup.chunks = append(rowGroup.chunks, chunk)
+	}
+
+	f.rowGroups = append(f.rowGroups, rowGroup)
+	f.rows = 0
+	return
+}
+
+func (f *parquetFile) close() (err error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.closed {
+		return
+	}
+	f.closed = true
+
+	err = f.flush()
+	if err != nil {
+		return
+	}
+
+	metadata := f.getMetadata()
+	err = f.write(metadata)
+	if err != nil {
+		return
+	}
+
+	length := make([]byte, 4)
+	binary.LittleEndian.PutUint32(length, uint32(len(metadata)))
+	err = f.write(length)
+	if err != nil {
+		return
+	}
+	return f.write([]byte(parquetMagic))
+}
+
+// getMetadata encodes the FileMetaData of the file
+func (f *parquetFile) getMetadata() []byte {
+	var totalRows int64
+	for _, rowGroup := range f.rowGroups {
+		totalRows += int64(rowGroup.rows)
+	}
+
+	t := &thriftWriter{}
+	t.writeI32(1, 1)
+
+	t.beginList(2, thriftStruct, len(f.columns)+1)
+	t.beginListStruct()
+	t.writeBinary(4, "schema")
+	t.writeI32(5, int32(len(f.columns)))
+	t.endStruct()
+	for _, column := range f.columns {
+		t.beginListStruct()
+		t.writeI32(1, column.physicalType)
+		if column.optional {
+			t.writeI32(3, parquetRepetitionOptional)
+		} else {
+			t.writeI32(3, parquetRepetitionRequired)
+		}
+		t.writeBinary(4, column.name)
+		if column.convertedType != parquetConvertedNone {
+			t.writeI32(6, column.convertedType)
+		}
+		t.endStruct()
+	}
+
+	t.writeI64(3, totalRows)
+
+	t.beginList(4, thriftStruct, len(f.rowGroups))
+	for _, rowGroup := range f.rowGroups {
+		t.beginListStruct()
+		t.beginList(1, thriftStruct, len(rowGroup.chunks))
+		for i, chunk := range rowGroup.chunks {
+			column := f.columns[i]
+			t.beginListStruct()
+			t.writeI64(2, chunk.offset)
+			t.beginStruct(3)
+			t.writeI32(1, column.physicalType)
+			t.beginList(2, thriftI32, 2)
+			t.writeVarint(parquetEncodingPlain)
+			t.writeVarint(parquetEncodingRLE)
+			t.beginList(3, thriftBinary, 1)
+			t.writeString(column.name)
+			t.writeI32(4, 0) // Uncompressed
+			t.writeI64(5, int64(rowGroup.rows))
+			t.writeI64(6, chunk.size)
+			t.writeI64(7, chunk.size)
+			t.writeI64(9, chunk.offset)
+			t.endStruct()
+			t.endStruct()
+		}
+		t.writeI64(2, rowGroup.size)
+		t.writeI64(3, int64(rowGroup.rows))
+		t.endStruct()
+	}
+
+	t.writeBinary(6, "tradingview-scraper")
+	t.endStruct()
+	return t.buffer.Bytes()
+}
+
+// encodeDefinitionLevels encodes the definition levels of a page, 1 for the values and 0 for the nulls, bit-packed
+// with the RLE hybrid encoding and prefixed by their length as the data pages of version 1 have them
+func encodeDefinitionLevels(defined []bool) []byte {
+	groups := (len(defined) + 7) / 8
+
+	encoded := &bytes.Buffer{}
+	header := make([]byte, binary.MaxVarintLen64)
+	encoded.Write(header[:binary.PutUvarint(header, uint64(groups)<<1|1)])
+	packed := make([]byte, groups)
+	for i, isDefined := range defined {
+		if isDefined {
+			packed[i/8] |= 1 << (i % 8)
+		}
+	}
+	encoded.Write(packed)
+
+	levels := make([]byte, 4, 4+encoded.Len())
+	binary.LittleEndian.PutUint32(levels, uint32(encoded.Len()))
+	return append(levels, encoded.Bytes()...)
+}
+
+func (f *parquetFile) write(p []byte) (err error) {
+	n, err := f.w.Write(p)
+	f.offset += int64(n)
+	return
+}
+
+// thriftWriter encodes structs with the Thrift compact protocol. The top level struct is implicit,
+// endStruct must be called to end it as the rest.
+type thriftWriter struct {
+	buffer bytes.Buffer
+	// lastFields has the id of the last field of every open struct
+	lastFields []int16
+}
+
+func (t *thriftWriter) writeFieldHeader(id int16, fieldType byte) {
+	if len(t.lastFields) == 0 {
+		t.lastFields = append(t.lastFields, 0)
+	}
+	last := &t.lastFields[len(t.lastFields)-1]
+
+	if delta := id - *last; delta > 0 && delta <= 15 {
+		t.buffer.WriteByte(byte(delta)<<4 | fieldType)
+	} else {
+		t.buffer.WriteByte(fieldType)
+		t.writeVarint(int64(id))
+	}
+	*last = id
+}
+
+// writeVarint writes the zigzag varint of the integers
+func (t *thriftWriter) writeVarint(value int64) {
+	t.writeUvarint(uint64((value << 1) ^ (value >> 63)))
+}
+
+func (t *thriftWriter) writeUvarint(value uint64) {
+	buf := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(buf, value)
+	t.buffer.Write(buf[:n])
+}
+
+func (t *thriftWriter) writeI32(id int16, value int32) {
+	t.writeFieldHeader(id, thriftI32)
+	t.writeVarint(int64(value))
+}
+
+func (t *thriftWriter) writeI64(id int16, value int64) {
+	t.writeFieldHeader(id, thriftI64)
+	t.writeVarint(value)
+}
+
+func (t *thriftWriter) writeBinary(id int16, value string) {
+	t.writeFieldHeader(id, thriftBinary)
+	t.writeString(value)
+}
+
+// writeString writes a string without field header, as the items of the lists
+func (t *thriftWriter) writeString(value string) {
+	t.writeUvarint(uint64(len(value)))
+	t.buffer.WriteString(value)
+}
+
+func (t *thriftWriter) beginStruct(id int16) {
+	t.writeFieldHeader(id, thriftStruct)
+	t.lastFields = append(t.lastFields, 0)
+}
+
+// beginListStruct begins a struct that is an item of a list
+func (t *thriftWriter) beginListStruct() {
+	if len(t.lastFields) == 0 {
+		t.lastFields = append(t.lastFields, 0)
+	}
+	t.lastFields = append(t.lastFields, 0)
+}
+
+func (t *thriftWriter) endStruct() {
+	t.buffer.WriteByte(0)
+	if len(t.lastFields) > 0 {
+		t.lastFields = t.lastFields[:len(t.lastFields)-1]
+	}
+}
+
+func (t *thriftWriter) beginList(id int16, elementType byte, size int) {
+	t.writeFieldHeader(id, thriftList)
+	if size < 15 {
+		t.buffer.WriteByte(byte(size)<<4 | elementType)
+		return
+	}
+	t.buffer.WriteByte(0xF0 | elementType)
+	t.writeUvarint(uint64(size))
+}
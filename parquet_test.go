@@ -0,0 +1,215 @@
+package tradingview
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestTickParquetWriter(t *testing.T) {
+	price := func(value float64) *float64 { return &value }
+	receivedAt := time.Date(2024, 3, 11, 14, 30, 0, 0, time.UTC)
+	ticks := []*QuoteData{
+		{ReceivedAt: receivedAt, Price: price(170.5), Volume: price(1000), Bid: price(170.4), Ask: price(170.6)},
+		{ReceivedAt: receivedAt.Add(time.Second), Price: price(170.7)},
+		{ReceivedAt: receivedAt.Add(2 * time.Second), Bid: price(170.6), Ask: price(170.8)},
+	}
+
+	file := &bytes.Buffer{}
+	writer, err := NewTickParquetWriter(file, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tick := range ticks {
+		err = writer.WriteTick("NASDAQ:AAPL", tick)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = writer.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content := file.Bytes()
+	if string(content[:4]) != parquetMagic || string(content[len(content)-4:]) != parquetMagic {
+		t.Fatal("the file doesn't start and end with the magic number")
+	}
+	footerLength := int(binary.LittleEndian.Uint32(content[len(content)-8:]))
+	footer := &thriftReader{data: content[len(content)-8-footerLength : len(content)-8]}
+	metadata := footer.readStruct()
+	if footer.pos != footerLength {
+		t.Fatalf("the footer has %d bytes, %d decoded", footerLength, footer.pos)
+	}
+
+	if rows := metadata[3].(int64); rows != int64(len(ticks)) {
+		t.Fatalf("expected %d rows, got %d", len(ticks), rows)
+	}
+
+	schema := metadata[2].([]interface{})
+	optional := map[string]bool{}
+	for _, element := range schema[1:] {
+		fields := element.(map[int16]interface{})
+		optional[fields[4].(string)] = fields[3].(int64) == parquetRepetitionOptional
+	}
+	if optional["time"] || optional["symbol"] || !optional["price"] || !optional["ask"] {
+		t.Fatalf("only the prices and the volume should be optional, got %v", optional)
+	}
+
+	var times []int64
+	var prices, bids []*float64
+	rowGroups := metadata[4].([]interface{})
+	if len(rowGroups) != 2 {
+		t.Fatalf("expected 2 row groups, got %d", len(rowGroups))
+	}
+	for _, rowGroup := range rowGroups {
+		fields := rowGroup.(map[int16]interface{})
+		rows := int(fields[3].(int64))
+		for i, chunk := range fields[1].([]interface{}) {
+			chunkFields := chunk.(map[int16]interface{})
+			columnMetadata := chunkFields[3].(map[int16]interface{})
+			offset := columnMetadata[9].(int64)
+			if offset != chunkFields[2].(int64) {
+				t.Fatalf("the chunk is at %d but its page at %d", chunkFields[2].(int64), offset)
+			}
+			if columnMetadata[5].(int64) != int64(rows) {
+				t.Fatalf("expected %d values in the chunk, got %d", rows, columnMetadata[5].(int64))
+			}
+
+			page := &thriftReader{data: content[offset : offset+columnMetadata[6].(int64)]}
+			header := page.readStruct()
+			if header[2].(int64) != int64(len(page.data)-page.pos) {
+				t.Fatalf("the page has %d bytes, the header says %d", len(page.data)-page.pos, header[2].(int64))
+			}
+			if values := header[5].(map[int16]interface{})[1].(int64); values != int64(rows) {
+				t.Fatalf("expected %d values in the page, got %d", rows, values)
+			}
+			data := page.data[page.pos:]
+
+			switch i {
+			case 0:
+				for j := 0; j < rows; j++ {
+					times = append(times, int64(binary.LittleEndian.Uint64(data[j*8:])))
+				}
+			case 2, 4:
+				values := decodeOptionalDoubles(t, data, rows)
+				if i == 2 {
+					prices = append(prices, values...)
+				} else {
+					bids = append(bids, values...)
+				}
+			}
+		}
+	}
+
+	for i, tick := range ticks {
+		if times[i] != getUnixMilli(tick.ReceivedAt) {
+			t.Fatalf("expected the time %d in the row %d, got %d", getUnixMilli(tick.ReceivedAt), i, times[i])
+		}
+		if !equalOptionalDouble(prices[i], tick.Price) {
+			t.Fatalf("expected the price %v in the row %d, got %v", tick.Price, i, prices[i])
+		}
+		if !equalOptionalDouble(bids[i], tick.Bid) {
+			t.Fatalf("expected the bid %v in the row %d, got %v", tick.Bid, i, bids[i])
+		}
+	}
+}
+
+// decodeOptionalDoubles decodes the bit-packed definition levels and the plain values of a page
+func decodeOptionalDoubles(t *testing.T, data []byte, rows int) (values []*float64) {
+	length := int(binary.LittleEndian.Uint32(data))
+	levels := data[4 : 4+length]
+	header, n := binary.Uvarint(levels)
+	if header&1 != 1 || int(header>>1) != (rows+7)/8 {
+		t.Fatalf("expected %d bit-packed groups, got the header %d", (rows+7)/8, header)
+	}
+	packed := levels[n:]
+
+	plain := data[4+length:]
+	for i := 0; i < rows; i++ {
+		if packed[i/8]&(1<<(i%8)) == 0 {
+			values = append(values, nil)
+			continue
+		}
+		value := math.Float64frombits(binary.LittleEndian.Uint64(plain))
+		plain = plain[8:]
+		values = append(values, &value)
+	}
+	if len(plain) != 0 {
+		t.Fatalf("%d bytes left after the values", len(plain))
+	}
+	return
+}
+
+func equalOptionalDouble(a *float64, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// thriftReader decodes the structs of the Thrift compact protocol written by thriftWriter,
+// as maps of the field ids to int64, string, []interface{} and map[int16]interface{}
+type thriftReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *thriftReader) readStruct() map[int16]interface{} {
+	fields := map[int16]interface{}{}
+	var last int16
+	for {
+		b := r.data[r.pos]
+		r.pos++
+		if b == 0 {
+			return fields
+		}
+
+		id := last + int16(b>>4)
+		if b>>4 == 0 {
+			id = int16(r.readVarint())
+		}
+		fields[id] = r.readValue(b & 0x0F)
+		last = id
+	}
+}
+
+func (r *thriftReader) readValue(valueType byte) interface{} {
+	switch valueType {
+	case thriftI32, thriftI64:
+		return r.readVarint()
+	case thriftBinary:
+		length := int(r.readUvarint())
+		value := string(r.data[r.pos : r.pos+length])
+		r.pos += length
+		return value
+	case thriftList:
+		b := r.data[r.pos]
+		r.pos++
+		size := int(b >> 4)
+		if size == 15 {
+			size = int(r.readUvarint())
+		}
+		items := make([]interface{}, size)
+		for i := range items {
+			items[i] = r.readValue(b & 0x0F)
+		}
+		return items
+	case thriftStruct:
+		return r.readStruct()
+	}
+	panic("unexpected thrift type")
+}
+
+func (r *thriftReader) readUvarint() uint64 {
+	value, n := binary.Uvarint(r.data[r.pos:])
+	r.pos += n
+	return value
+}
+
+func (r *thriftReader) readVarint() int64 {
+	value := r.readUvarint()
+	return int64(value>>1) ^ -int64(value&1)
+}
@@ -0,0 +1,157 @@
+package tradingview
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// PineFacadeURL ...
+const PineFacadeURL = "https://pine-facade.tradingview.com/pine-facade/translate/"
+
+// pineStudyScript is the study that runs the compiled Pine scripts
+const pineStudyScript = "Script@tv-scripting-101!"
+
+// PineScript - Published Pine script compiled by TradingView, ready to be added to a chart session
+type PineScript struct {
+	ID      string
+	Version string
+	Name    string
+	// Text is the compiled script sent to the server
+	Text   string
+	Inputs []*PineInput
+	// Plots are the titles of the plots, in the same order as the values of the study
+	Plots []string
+}
+
+// PineInput - Input of a Pine script, with the value the script uses when it's not given
+type PineInput struct {
+	ID      string
+	Name    string
+	Type    string
+	Default interface{}
+}
+
+type pineTranslateResponse struct {
+	Success bool   `json:"success"`
+	Reason  string `json:"reason"`
+	Result  struct {
+		ILTemplate string `json:"ilTemplate"`
+		MetaInfo   struct {
+			Description string `json:"description"`
+			Inputs      []struct {
+				ID       string      `json:"id"`
+				Name     string      `json:"name"`
+				Type     string      `json:"type"`
+				Default  interface{} `json:"defval"`
+				IsHidden bool        `json:"isHidden"`
+			} `json:"inputs"`
+			Plots []struct {
+				ID string `json:"id"`
+			} `json:"plots"`
+			Styles map[string]struct {
+				Title string `json:"title"`
+			} `json:"styles"`
+		} `json:"metaInfo"`
+	} `json:"result"`
+}
+
+// GetPineScript gets a published Pine script by its id (PUB;...) and version, "last" for the latest one.
+// The HTTP client can be nil to use http.DefaultClient.
+func GetPineScript(ctx context.Context, client *http.Client, id string, version string) (script *PineScript, err error) {
+	if id == "" {
+		return nil, errors.New("the id of the Pine script can't be empty")
+	}
+	if version == "" {
+		version = "last"
+	}
+
+	req, err := newHTTPRequest(ctx, http.MethodGet, PineFacadeURL+url.PathEscape(id)+"/"+url.PathEscape(version), nil)
+	if err != nil {
+		return
+	}
+
+	var res *pineTranslateResponse
+	_, err = doHTTPRequest(client, req, &res)
+	if err != nil {
+		return
+	}
+	if res == nil || !res.Success {
+		reason := "empty response"
+		if res != nil {
+			reason = res.Reason
+		}
+		return nil, errors.New("the Pine script " + id + " couldn't be translated: " + reason)
+	}
+
+	meta := res.Result.MetaInfo
+	script = &PineScript{ID: id, Version: version, Name: meta.Description, Text: res.Result.ILTemplate}
+	for _, input := range meta.Inputs {
+		// The hidden ones are the text, id and version of the script
+		if input.IsHidden || !strings.HasPrefix(input.ID, "in_") {
+			continue
+		}
+		script.Inputs = append(script.Inputs, &PineInput{
+			ID:      input.ID,
+			Name:    input.Name,
+			Type:    input.Type,
+			Default: input.Default,
+		})
+	}
+	for _, plot := range meta.Plots {
+		title := meta.Styles[plot.ID].Title
+		if title == "" {
+			title = plot.ID
+		}
+		script.Plots = append(script.Plots, title)
+	}
+	return
+}
+
+// PlotValues returns the values of the study by the title of their plots, without the plots that have no value for the bar
+func (p *PineScript) PlotValues(value *StudyValue) map[string]float64 {
+	values := map[string]float64{}
+	for i, title := range p.Plots {
+		if i < len(value.Values) && !math.IsNaN(value.Values[i]) {
+			values[title] = value.Values[i]
+		}
+	}
+	return values
+}
+
+// AddPineScript adds the Pine script to the chart session. The inputs are given by their name or id,
+// and the ones not given use their default value. onValue receives the values of the plots, in the order of script.Plots.
+func (cs *ChartSession) AddPineScript(
+	script *PineScript,
+	inputs map[string]interface{},
+	onValue OnStudyValueCallback,
+) (study *Study, err error) {
+	if script == nil || script.Text == "" {
+		return nil, errors.New("the Pine script must be loaded with GetPineScript")
+	}
+
+	return cs.AddStudy(pineStudyScript, script.getStudyInputs(inputs), onValue)
+}
+
+// getStudyInputs returns the inputs of the study that runs the script
+func (p *PineScript) getStudyInputs(inputs map[string]interface{}) map[string]interface{} {
+	studyInputs := map[string]interface{}{
+		"text":        p.Text,
+		"pineId":      p.ID,
+		"pineVersion": p.Version,
+	}
+	for _, input := range p.Inputs {
+		value, ok := inputs[input.Name]
+		if !ok {
+			value, ok = inputs[input.ID]
+		}
+		if !ok {
+			value = input.Default
+		}
+		studyInputs[input.ID] = map[string]interface{}{"v": value, "f": true, "t": input.Type}
+	}
+	return studyInputs
+}
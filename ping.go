@@ -0,0 +1,77 @@
+package tradingview
+
+import (
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// latencySmoothingFactor is the weight of the newest round-trip in the rolling latency estimate
+const latencySmoothingFactor = 0.2
+
+func (s *Socket) setPongHandler() {
+	atomic.StoreInt64(&s.lastPongAt, time.Now().UnixNano())
+
+	transport, ok := s.getTransport().(PingTransport)
+	if !ok {
+		return
+	}
+
+	transport.SetPongHandler(func(appData string) error {
+		now := time.Now().UnixNano()
+		atomic.StoreInt64(&s.lastPongAt, now)
+
+		if sentAt, err := strconv.ParseInt(appData, 10, 64); err == nil && sentAt <= now {
+			s.recordLatency(time.Duration(now - sentAt))
+		}
+		return nil
+	})
+}
+
+// recordLatency updates the rolling latency estimate with an exponentially weighted moving average
+func (s *Socket) recordLatency(roundTrip time.Duration) {
+	for {
+		previous := atomic.LoadInt64(&s.latency)
+		next := int64(roundTrip)
+		if previous != 0 {
+			next = int64(float64(previous)*(1-latencySmoothingFactor) + float64(roundTrip)*latencySmoothingFactor)
+		}
+		if atomic.CompareAndSwapInt64(&s.latency, previous, next) {
+			return
+		}
+	}
+}
+
+// Latency returns the rolling estimate of the round-trip time to the server, measured with the ping frames.
+// It's 0 until the first pong is received, so WithPing must be used to get a value.
+func (s *Socket) Latency() time.Duration {
+	return time.Duration(atomic.LoadInt64(&s.latency))
+}
+
+// pingLoop keeps pinging the given transport until it's closed or replaced by a reconnection
+func (s *Socket) pingLoop(transport PingTransport) {
+	ticker := time.NewTicker(s.pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		lastPongAt := time.Unix(0, atomic.LoadInt64(&s.lastPongAt))
+		if time.Since(lastPongAt) > s.pingInterval+s.pingTimeout {
+			s.onError(errors.New("no pong received since "+lastPongAt.String()), PingTimeoutErrorContext)
+			return
+		}
+
+		// The pong echoes the payload back, the send time in it is used to measure the latency
+		sentAt := []byte(strconv.FormatInt(time.Now().UnixNano(), 10))
+		err := transport.WritePing(sentAt, time.Now().Add(s.pingTimeout))
+		if err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package tradingview
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// SocketPool shards the symbols across several sockets, since a single quote session degrades
+// (or gets rejected) when it has to deal with thousands of symbols.
+// Every symbol is added to the socket with the fewest symbols.
+type SocketPool struct {
+	sockets     []*Socket
+	assignments map[string]*Socket
+	// load has the number of symbols assigned to every socket
+	load  map[*Socket]int
+	mutex sync.Mutex
+}
+
+// ConnectPool - Connects size sockets with the same callbacks and options and returns the pool
+func ConnectPool(
+	size int,
+	onReceiveMarketDataCallback OnReceiveDataCallback,
+	onErrorCallback OnErrorCallback,
+	options ...Option,
+) (pool *SocketPool, err error) {
+	return ConnectPoolWithContext(context.Background(), size, onReceiveMarketDataCallback, onErrorCallback, options...)
+}
+
+// ConnectPoolWithContext - Same as ConnectPool, but cancelling the context closes all the sockets
+func ConnectPoolWithContext(
+	ctx context.Context,
+	size int,
+	onReceiveMarketDataCallback OnReceiveDataCallback,
+	onErrorCallback OnErrorCallback,
+	options ...Option,
+) (pool *SocketPool, err error) {
+	if size <= 0 {
+		return nil, errors.New("the pool size must be positive")
+	}
+
+	p := &SocketPool{assignments: map[string]*Socket{}, load: map[*Socket]int{}}
+	for i := 0; i < size; i++ {
+		var socket SocketInterface
+		socket, err = ConnectWithContext(ctx, onReceiveMarketDataCallback, onErrorCallback, options...)
+		if err != nil {
+			p.Close()
+			return
+		}
+		p.sockets = append(p.sockets, socket.(*Socket))
+	}
+
+	pool = p
+	return
+}
+
+// AddSymbol adds the symbol to the socket with the fewest symbols
+func (p *SocketPool) AddSymbol(symbol string) (err error) {
+	p.mutex.Lock()
+	socket, isAssigned := p.assignments[symbol]
+	if !isAssigned {
+		socket = p.leastLoadedSocket()
+		p.assign(symbol, socket)
+	}
+	p.mutex.Unlock()
+
+	err = socket.AddSymbol(symbol)
+	if err != nil && !isAssigned {
+		p.mutex.Lock()
+		p.unassign(symbol, socket)
+		p.mutex.Unlock()
+	}
+	return
+}
+
+// AddSymbols spreads the symbols across the sockets, sending a single message to each one
+func (p *SocketPool) AddSymbols(symbols []string) (err error) {
+	p.mutex.Lock()
+	bySocket := map[*Socket][]string{}
+	// assigned has the symbols assigned by this call, which are forgotten if their socket fails to add them
+	assigned := map[*Socket][]string{}
+	for _, symbol := range symbols {
+		socket, isAssigned := p.assignments[symbol]
+		if !isAssigned {
+			socket = p.leastLoadedSocket()
+			p.assign(symbol, socket)
+			assigned[socket] = append(assigned[socket], symbol)
+		}
+		bySocket[socket] = append(bySocket[socket], symbol)
+	}
+	p.mutex.Unlock()
+
+	for socket, socketSymbols := range bySocket {
+		if err == nil {
+			err = socket.AddSymbols(socketSymbols)
+			if err == nil {
+				continue
+			}
+		}
+
+		// The sockets after the failed one are not sent either
+		p.mutex.Lock()
+		for _, symbol := range assigned[socket] {
+			p.unassign(symbol, socket)
+		}
+		p.mutex.Unlock()
+	}
+	return
+}
+
+// RemoveSymbols removes the symbols from their sockets, sending a single message to each one
+func (p *SocketPool) RemoveSymbols(symbols []string) (err error) {
+	p.mutex.Lock()
+	bySocket := map[*Socket][]string{}
+	for _, symbol := range symbols {
+		if socket, isAssigned := p.assignments[symbol]; isAssigned {
+			bySocket[socket] = append(bySocket[socket], symbol)
+			p.unassign(symbol, socket)
+		}
+	}
+	p.mutex.Unlock()
+
+	for socket, socketSymbols := range bySocket {
+		err = socket.RemoveSymbols(socketSymbols)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// RemoveSymbol ...
+func (p *SocketPool) RemoveSymbol(symbol string) (err error) {
+	p.mutex.Lock()
+	socket, isAssigned := p.assignments[symbol]
+	p.unassign(symbol, socket)
+	p.mutex.Unlock()
+
+	if !isAssigned {
+		return
+	}
+	return socket.RemoveSymbol(symbol)
+}
+
+// Close closes all the sockets, returning the first error found
+func (p *SocketPool) Close() (err error) {
+	for _, socket := range p.sockets {
+		if closeErr := socket.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	return
+}
+
+// Shutdown shuts down all the sockets concurrently, returning the first error found
+func (p *SocketPool) Shutdown(ctx context.Context) (err error) {
+	errs := make(chan error, len(p.sockets))
+	for _, socket := range p.sockets {
+		go func(socket *Socket) {
+			errs <- socket.Shutdown(ctx)
+		}(socket)
+	}
+
+	for range p.sockets {
+		if shutdownErr := <-errs; shutdownErr != nil && err == nil {
+			err = shutdownErr
+		}
+	}
+	return
+}
+
+// Sockets returns the sockets of the pool, useful to check the health of each connection
+func (p *SocketPool) Sockets() []SocketInterface {
+	sockets := make([]SocketInterface, 0, len(p.sockets))
+	for _, socket := range p.sockets {
+		sockets = append(sockets, socket)
+	}
+	return sockets
+}
+
+// IsConnected tells if all the sockets are connected
+func (p *SocketPool) IsConnected() bool {
+	for _, socket := range p.sockets {
+		if !socket.IsConnected() {
+			return false
+		}
+	}
+	return true
+}
+
+// LastMessageAt returns when the last quote was received by any of the sockets
+func (p *SocketPool) LastMessageAt() (lastMessageAt time.Time) {
+	for _, socket := range p.sockets {
+		if t := socket.LastMessageAt(); t.After(lastMessageAt) {
+			lastMessageAt = t
+		}
+	}
+	return
+}
+
+// leastLoadedSocket returns the socket with the fewest symbols, the mutex must be held
+func (p *SocketPool) leastLoadedSocket() *Socket {
+	leastLoaded := p.sockets[0]
+	for _, socket := range p.sockets[1:] {
+		if p.load[socket] < p.load[leastLoaded] {
+			leastLoaded = socket
+		}
+	}
+	return leastLoaded
+}
+
+// assign records the symbol as added to the socket, the mutex must be held
+func (p *SocketPool) assign(symbol string, socket *Socket) {
+	p.assignments[symbol] = socket
+	p.load[socket]++
+}
+
+// unassign forgets the symbol if it's still assigned to the socket, the mutex must be held
+func (p *SocketPool) unassign(symbol string, socket *Socket) {
+	if assigned, isAssigned := p.assignments[symbol]; !isAssigned || assigned != socket {
+		return
+	}
+	delete(p.assignments, symbol)
+	p.load[socket]--
+}
@@ -0,0 +1,73 @@
+package tradingview
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// TickSize returns the minimum price change of the symbol, 0 if the pricescale is unknown
+func (i *SymbolInfo) TickSize() float64 {
+	if i == nil || i.PriceScale <= 0 {
+		return 0
+	}
+
+	minMove := i.MinMove
+	if minMove <= 0 {
+		minMove = 1
+	}
+	return float64(minMove) / float64(i.PriceScale)
+}
+
+// Decimals returns the number of decimals of the prices of the symbol, 0 for the fractional ones
+func (i *SymbolInfo) Decimals() int {
+	if i == nil || i.Fractional || i.PriceScale <= 1 {
+		return 0
+	}
+	return int(math.Ceil(math.Log10(float64(i.PriceScale))))
+}
+
+// RoundPrice rounds the price to the nearest valid tick. The price is returned as it is if the pricescale is unknown.
+func (i *SymbolInfo) RoundPrice(price float64) float64 {
+	tickSize := i.TickSize()
+	if tickSize == 0 {
+		return price
+	}
+
+	rounded := math.Round(price/tickSize) * tickSize
+	if i.Fractional {
+		return rounded
+	}
+
+	// Removes the floating point noise of the multiplication, like 1.1000000000000001
+	rounded, _ = strconv.ParseFloat(strconv.FormatFloat(rounded, 'f', i.Decimals(), 64), 64)
+	return rounded
+}
+
+// FormatPrice rounds the price to a valid tick and formats it as TradingView does,
+// with the decimals of the pricescale, or as whole'numerator for the fractional prices
+func (i *SymbolInfo) FormatPrice(price float64) string {
+	rounded := i.RoundPrice(price)
+	if i == nil || !i.Fractional || i.PriceScale <= 0 {
+		return strconv.FormatFloat(rounded, 'f', i.Decimals(), 64)
+	}
+
+	denominator := i.PriceScale
+	if i.MinMove2 > 0 {
+		denominator = i.PriceScale / i.MinMove2
+	}
+
+	sign := ""
+	if rounded < 0 {
+		sign = "-"
+		rounded = -rounded
+	}
+	whole := math.Floor(rounded)
+	numerator := strconv.FormatFloat(math.Round((rounded-whole)*float64(denominator)*1000)/1000, 'f', -1, 64)
+	width := len(strconv.Itoa(denominator - 1))
+	if integerDigits := len(strings.SplitN(numerator, ".", 2)[0]); integerDigits < width {
+		numerator = strings.Repeat("0", width-integerDigits) + numerator
+	}
+
+	return sign + strconv.FormatFloat(whole, 'f', 0, 64) + "'" + numerator
+}
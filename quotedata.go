@@ -0,0 +1,33 @@
+package tradingview
+
+import (
+	"math"
+	"time"
+)
+
+// LastPriceAt returns the time of the last trade, or the zero time if the update doesn't include it
+func (d *QuoteData) LastPriceAt() time.Time {
+	if d == nil || d.LastPriceTime == nil {
+		return time.Time{}
+	}
+	return time.Unix(*d.LastPriceTime, 0)
+}
+
+// ExtendedPriceAt returns the time of the last trade in the pre-market or after-hours session,
+// or the zero time if the update doesn't include it
+func (d *QuoteData) ExtendedPriceAt() time.Time {
+	if d == nil || d.ExtendedPriceTime == nil {
+		return time.Time{}
+	}
+	return time.Unix(*d.ExtendedPriceTime, 0)
+}
+
+// IsGapper tells if the symbol is in the pre-market with a change of at least minPercent (positive or negative)
+// from the previous close. It needs the ExtendedSessionFields, and the merged data of GetLatest to not miss
+// the fields that didn't change in the update.
+func (d *QuoteData) IsGapper(minPercent float64) bool {
+	if d == nil || d.TradingSession != TradingSessionPreMarket || d.ExtendedChangePercent == nil {
+		return false
+	}
+	return math.Abs(*d.ExtendedChangePercent) >= minPercent
+}
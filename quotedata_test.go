@@ -0,0 +1,38 @@
+package tradingview
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIsGapperOnLatest(t *testing.T) {
+	s := &Socket{
+		sessionPrefix:   DefaultSessionPrefix,
+		sessionIDLength: DefaultSessionIDLength,
+		sequencer:       newSequencer(),
+	}
+	s.quoteSession = s.newQuoteSession(nil, nil)
+	s.quoteSessions = map[string]*QuoteSession{s.quoteSession.SessionID(): s.quoteSession}
+	s.quoteSession.symbols["NASDAQ:AAPL"] = true
+
+	msg := `{"m":"qsd","p":["` + s.quoteSession.SessionID() + `",{"n":"NASDAQ:AAPL","s":"ok","v":` +
+		`{"current_session":"pre_market","rchp":5.5}}]}`
+	_, _, data, err := s.parseJSON([]byte(msg), time.Now(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !data.IsGapper(5) {
+		t.Fatal("the update should be a gapper")
+	}
+
+	latest, ok := s.GetLatest("NASDAQ:AAPL")
+	if !ok {
+		t.Fatal("no latest data")
+	}
+	if latest.TradingSession != TradingSessionPreMarket {
+		t.Fatalf("expected the pre-market session in the latest data, got %q", latest.TradingSession)
+	}
+	if !latest.IsGapper(5) {
+		t.Fatal("the latest data should be a gapper")
+	}
+}
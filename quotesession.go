@@ -0,0 +1,319 @@
+package tradingview
+
+import (
+	"errors"
+	"sync"
+)
+
+// defaultQuoteFields are the fields requested by a quote session when none are given
+var defaultQuoteFields = []string{FieldLastPrice, FieldVolume, FieldBid, FieldAsk, FieldBidSize, FieldAskSize, FieldUpdateMode, FieldLastPriceTime}
+
+// QuoteSession - Quote session with its own fields and symbols.
+// TradingView allows several quote sessions on the same connection, useful for example to separate
+// the fast moving symbols from a slow watchlist.
+type QuoteSession struct {
+	socket        *Socket
+	onReceiveData OnReceiveDataCallback
+
+	id              string
+	fields          []string
+	symbols         map[string]bool
+	tradingSessions map[string]TradingSession
+	snapshots       map[string]*QuoteData
+	paused          bool
+	pendingAcks     map[string][]chan error
+	lastVolumes     map[string]float64
+	halted          map[string]bool
+	mutex           sync.RWMutex
+}
+
+// NewQuoteSession creates a new quote session on the connection of the socket.
+// The data of its symbols is delivered to onReceiveData instead of the socket callback.
+// If no fields are given, the default ones (lp, volume, bid, ask, bid_size, ask_size, update_mode and lp_time) are requested.
+func (s *Socket) NewQuoteSession(onReceiveData OnReceiveDataCallback, fields ...string) (session *QuoteSession, err error) {
+	if onReceiveData == nil {
+		return nil, errors.New("the data callback of the quote session can't be nil")
+	}
+
+	qs := s.newQuoteSession(onReceiveData, fields)
+	s.registerQuoteSession(qs)
+
+	err = qs.sendCreateMessages()
+	if err != nil {
+		s.unregisterQuoteSession(qs)
+		return
+	}
+
+	session = qs
+	return
+}
+
+func (s *Socket) newQuoteSession(onReceiveData OnReceiveDataCallback, fields []string) *QuoteSession {
+	if len(fields) == 0 {
+		fields = defaultQuoteFields
+	}
+
+	qs := &QuoteSession{
+		socket:          s,
+		onReceiveData:   onReceiveData,
+		fields:          append([]string(nil), fields...),
+		symbols:         map[string]bool{},
+		tradingSessions: map[string]TradingSession{},
+		snapshots:       map[string]*QuoteData{},
+		pendingAcks:     map[string][]chan error{},
+		lastVolumes:     map[string]float64{},
+		halted:          map[string]bool{},
+	}
+	qs.generateID()
+
+	return qs
+}
+
+// GetSessionID returns the id of the default quote session.
+// It changes when the session is created again, for example after a reconnection.
+func (s *Socket) GetSessionID() string {
+	if s.quoteSession == nil {
+		return ""
+	}
+	return s.quoteSession.SessionID()
+}
+
+// SessionID ...
+func (qs *QuoteSession) SessionID() string {
+	qs.mutex.RLock()
+	defer qs.mutex.RUnlock()
+
+	return qs.id
+}
+
+// AddSymbol ...
+func (qs *QuoteSession) AddSymbol(symbol string) (err error) {
+	return qs.AddSymbols([]string{symbol})
+}
+
+// AddSymbols adds all the symbols with a single quote_add_symbols message
+func (qs *QuoteSession) AddSymbols(symbols []string) (err error) {
+	if len(symbols) == 0 {
+		return errors.New("at least one symbol must be given")
+	}
+
+	qs.mutex.Lock()
+	for _, symbol := range symbols {
+		qs.symbols[symbol] = true
+	}
+	qs.mutex.Unlock()
+	qs.onSubscriptionsChange()
+
+	err = qs.sendAddSymbols(symbols)
+	return
+}
+
+// RemoveSymbol ...
+func (qs *QuoteSession) RemoveSymbol(symbol string) (err error) {
+	return qs.RemoveSymbols([]string{symbol})
+}
+
+// RemoveSymbols removes all the symbols with a single quote_remove_symbols message
+func (qs *QuoteSession) RemoveSymbols(symbols []string) (err error) {
+	if len(symbols) == 0 {
+		return errors.New("at least one symbol must be given")
+	}
+
+	qs.mutex.Lock()
+	for _, symbol := range symbols {
+		delete(qs.symbols, symbol)
+		delete(qs.tradingSessions, symbol)
+		delete(qs.snapshots, symbol)
+		delete(qs.lastVolumes, symbol)
+		delete(qs.halted, symbol)
+	}
+	qs.mutex.Unlock()
+	qs.onSubscriptionsChange()
+
+	payload := []interface{}{qs.SessionID()}
+	for _, symbol := range symbols {
+		payload = append(payload, symbol)
+	}
+	err = qs.socket.sendSocketMessage(getSocketMessage("quote_remove_symbols", payload))
+	return
+}
+
+func (qs *QuoteSession) sendAddSymbols(symbols []string) error {
+	payload := []interface{}{qs.SessionID()}
+	for _, symbol := range symbols {
+		payload = append(payload, symbol)
+	}
+	payload = append(payload, getFlags())
+
+	return qs.socket.sendSocketMessage(getSocketMessage("quote_add_symbols", payload))
+}
+
+// SetFields changes the fields requested by the quote session, without reconnecting nor subscribing again to the symbols
+func (qs *QuoteSession) SetFields(fields ...string) (err error) {
+	if len(fields) == 0 {
+		return errors.New("at least one field must be given")
+	}
+
+	qs.mutex.Lock()
+	qs.fields = append([]string(nil), fields...)
+	qs.mutex.Unlock()
+	qs.onSubscriptionsChange()
+
+	err = qs.socket.sendSocketMessage(
+		getSocketMessage("quote_set_fields", append([]string{qs.SessionID()}, qs.getRequestedFields()...)),
+	)
+	return
+}
+
+// Close deletes the quote session from the server. The connection and the rest of the sessions remain open.
+func (qs *QuoteSession) Close() (err error) {
+	qs.socket.unregisterQuoteSession(qs)
+
+	err = qs.socket.sendSocketMessage(
+		getSocketMessage("quote_delete_session", []string{qs.SessionID()}),
+	)
+	return
+}
+
+func (qs *QuoteSession) generateID() {
+	qs.mutex.Lock()
+	defer qs.mutex.Unlock()
+
+	qs.id = qs.socket.sessionPrefix + GetRandomString(qs.socket.sessionIDLength)
+}
+
+func (qs *QuoteSession) getSymbols() []string {
+	qs.mutex.RLock()
+	defer qs.mutex.RUnlock()
+
+	symbols := make([]string, 0, len(qs.symbols))
+	for symbol := range qs.symbols {
+		symbols = append(symbols, symbol)
+	}
+	return symbols
+}
+
+func (qs *QuoteSession) getFields() []string {
+	qs.mutex.RLock()
+	defer qs.mutex.RUnlock()
+
+	return append([]string(nil), qs.fields...)
+}
+
+// onSubscriptionsChange persists the state when it's the default session, the only one that can be restored
+func (qs *QuoteSession) onSubscriptionsChange() {
+	if qs == qs.socket.quoteSession {
+		qs.socket.saveState()
+	}
+}
+
+// getRequestedFields returns the fields of the session plus the ones needed by the callbacks of the socket
+func (qs *QuoteSession) getRequestedFields() []string {
+	fields := qs.getFields()
+	for _, field := range qs.socket.getCallbackFields() {
+		if !containsString(fields, field) {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// getCallbackFields returns the quote fields needed by the callbacks given with the options
+func (s *Socket) getCallbackFields() (fields []string) {
+	if s.onSymbolInfo != nil {
+		fields = append(fields, SymbolInfoFields...)
+	}
+	if s.onMarketStatus != nil {
+		fields = append(fields, FieldCurrentSession)
+	}
+	if s.onTradingHalt != nil {
+		fields = append(fields, HaltFields...)
+	}
+	if s.baseCurrency != "" {
+		fields = append(fields, FieldCurrency)
+	}
+	return
+}
+
+func (qs *QuoteSession) getCallback() OnReceiveDataCallback {
+	if qs.onReceiveData != nil {
+		return qs.onReceiveData
+	}
+	return qs.socket.OnReceiveMarketDataCallback
+}
+
+func (qs *QuoteSession) sendCreateMessages() (err error) {
+	sessionID := qs.SessionID()
+	messages := []*SocketMessage{
+		getSocketMessage("quote_create_session", []string{sessionID}),
+		getSocketMessage("quote_set_fields", append([]string{sessionID}, qs.getRequestedFields()...)),
+	}
+
+	for _, msg := range messages {
+		err = qs.socket.sendSocketMessage(msg)
+		if err != nil {
+			return
+		}
+	}
+
+	// The market status and the session times of the quotes follow the timezone of the user too
+	return qs.socket.sendTimezone(sessionID)
+}
+
+func (qs *QuoteSession) resubscribe() (err error) {
+	symbols := qs.getSymbols()
+	if len(symbols) > 0 {
+		err = qs.sendAddSymbols(symbols)
+		if err != nil {
+			return
+		}
+	}
+
+	// The new session is active, it has to be paused again
+	if qs.isPaused() {
+		err = qs.sendHibernate()
+	}
+	return
+}
+
+func (s *Socket) registerQuoteSession(qs *QuoteSession) {
+	s.quoteSessionsMutex.Lock()
+	defer s.quoteSessionsMutex.Unlock()
+
+	s.quoteSessions[qs.SessionID()] = qs
+}
+
+func (s *Socket) unregisterQuoteSession(qs *QuoteSession) {
+	s.quoteSessionsMutex.Lock()
+	defer s.quoteSessionsMutex.Unlock()
+
+	delete(s.quoteSessions, qs.SessionID())
+}
+
+func (s *Socket) getQuoteSession(sessionID string) *QuoteSession {
+	s.quoteSessionsMutex.RLock()
+	defer s.quoteSessionsMutex.RUnlock()
+
+	return s.quoteSessions[sessionID]
+}
+
+func (s *Socket) getQuoteSessions() []*QuoteSession {
+	s.quoteSessionsMutex.RLock()
+	defer s.quoteSessionsMutex.RUnlock()
+
+	sessions := make([]*QuoteSession, 0, len(s.quoteSessions))
+	for _, qs := range s.quoteSessions {
+		sessions = append(sessions, qs)
+	}
+	return sessions
+}
+
+// renewQuoteSessionID gives a new id to the session, needed every time it has to be created again on the server
+func (s *Socket) renewQuoteSessionID(qs *QuoteSession) {
+	s.quoteSessionsMutex.Lock()
+	defer s.quoteSessionsMutex.Unlock()
+
+	delete(s.quoteSessions, qs.SessionID())
+	qs.generateID()
+	s.quoteSessions[qs.SessionID()] = qs
+}
@@ -0,0 +1,61 @@
+package tradingview
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// tokenBucket allows bursts of up to burst messages and then paces them at rate messages per second
+type tokenBucket struct {
+	mutex      sync.Mutex
+	rate       float64
+	burst      float64
+	tokens     float64
+	lastRefill time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	return &tokenBucket{
+		rate:       rate,
+		burst:      float64(burst),
+		tokens:     float64(burst),
+		lastRefill: time.Now(),
+	}
+}
+
+// wait blocks until a token is available or the context is done
+func (tb *tokenBucket) wait(ctx context.Context) error {
+	for {
+		delay := tb.reserve()
+		if delay == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// reserve takes a token if there's one, otherwise it returns how long to wait until the next one
+func (tb *tokenBucket) reserve() time.Duration {
+	tb.mutex.Lock()
+	defer tb.mutex.Unlock()
+
+	now := time.Now()
+	tb.tokens += now.Sub(tb.lastRefill).Seconds() * tb.rate
+	if tb.tokens > tb.burst {
+		tb.tokens = tb.burst
+	}
+	tb.lastRefill = now
+
+	if tb.tokens >= 1 {
+		tb.tokens--
+		return 0
+	}
+
+	return time.Duration((1 - tb.tokens) / tb.rate * float64(time.Second))
+}
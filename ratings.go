@@ -0,0 +1,133 @@
+package tradingview
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// ScreenerColumnRating - Technical rating of the daily candles, see GetTechnicalRating
+const ScreenerColumnRating = "Recommend.All"
+
+// ScreenerColumnRatingMA - Technical rating of the moving averages of the daily candles
+const ScreenerColumnRatingMA = "Recommend.MA"
+
+// ScreenerColumnRatingOscillators - Technical rating of the oscillators of the daily candles
+const ScreenerColumnRatingOscillators = "Recommend.Other"
+
+// Rating - Gauge of the technical analysis of the website, from strong sell to strong buy
+type Rating string
+
+// RatingStrongSell ...
+const RatingStrongSell Rating = "STRONG_SELL"
+
+// RatingSell ...
+const RatingSell Rating = "SELL"
+
+// RatingNeutral ...
+const RatingNeutral Rating = "NEUTRAL"
+
+// RatingBuy ...
+const RatingBuy Rating = "BUY"
+
+// RatingStrongBuy ...
+const RatingStrongBuy Rating = "STRONG_BUY"
+
+// TechnicalRating - Value of a rating, from -1 (strong sell) to 1 (strong buy), and its gauge
+type TechnicalRating struct {
+	Value  float64
+	Rating Rating
+}
+
+// TechnicalRatings - Ratings of a symbol in a timeframe, as the technical analysis of the website:
+// the summary, and the ones of the moving averages and the oscillators it's computed from
+type TechnicalRatings struct {
+	Symbol         string
+	Timeframe      Timeframe
+	Summary        TechnicalRating
+	MovingAverages TechnicalRating
+	Oscillators    TechnicalRating
+}
+
+// ratingTimeframeSuffixes are the suffixes of the rating columns of every timeframe, the daily ones don't have
+var ratingTimeframeSuffixes = map[Timeframe]string{
+	Timeframe1Minute:   "|1",
+	Timeframe5Minutes:  "|5",
+	Timeframe15Minutes: "|15",
+	Timeframe30Minutes: "|30",
+	Timeframe1Hour:     "|60",
+	Timeframe2Hours:    "|120",
+	Timeframe4Hours:    "|240",
+	Timeframe1Day:      "",
+	Timeframe1Week:     "|1W",
+	Timeframe1Month:    "|1M",
+}
+
+// GetTechnicalRating returns the technical ratings of the symbol (NASDAQ:AAPL) in the timeframe, from the scanner
+// of its market (america, crypto, forex...). Only the timeframes of the website have ratings: 1, 5, 15 and 30 minutes,
+// 1, 2 and 4 hours, 1 day, 1 week and 1 month.
+// The HTTP client can be nil to use http.DefaultClient.
+func GetTechnicalRating(
+	ctx context.Context,
+	client *http.Client,
+	market string,
+	symbol string,
+	timeframe Timeframe,
+) (ratings *TechnicalRatings, err error) {
+	if symbol == "" {
+		return nil, errors.New("the symbol can't be empty")
+	}
+	suffix, ok := ratingTimeframeSuffixes[timeframe]
+	if !ok {
+		return nil, errors.New("there are no technical ratings for the timeframe " + string(timeframe))
+	}
+
+	summary := ScreenerColumnRating + suffix
+	movingAverages := ScreenerColumnRatingMA + suffix
+	oscillators := ScreenerColumnRatingOscillators + suffix
+	result, err := NewScreener(client, market).
+		Columns(summary, movingAverages, oscillators).
+		Tickers(symbol).
+		Scan(ctx)
+	if err != nil {
+		return
+	}
+	if len(result.Rows) == 0 {
+		return nil, errors.New("no technical ratings for " + symbol)
+	}
+
+	row := result.Rows[0]
+	if _, ok := row.Float(summary); !ok {
+		return nil, errors.New("no technical ratings for " + symbol + " in the timeframe " + string(timeframe))
+	}
+
+	ratings = &TechnicalRatings{
+		Symbol:         row.Symbol,
+		Timeframe:      timeframe,
+		Summary:        newTechnicalRating(row, summary),
+		MovingAverages: newTechnicalRating(row, movingAverages),
+		Oscillators:    newTechnicalRating(row, oscillators),
+	}
+	return
+}
+
+// GetRating returns the gauge of a rating value, with the same thresholds as the website
+func GetRating(value float64) Rating {
+	switch {
+	case value < -0.5:
+		return RatingStrongSell
+	case value < -0.1:
+		return RatingSell
+	case value <= 0.1:
+		return RatingNeutral
+	case value <= 0.5:
+		return RatingBuy
+	default:
+		return RatingStrongBuy
+	}
+}
+
+func newTechnicalRating(row *ScreenerRow, column string) TechnicalRating {
+	value, _ := row.Float(column)
+	return TechnicalRating{Value: value, Rating: GetRating(value)}
+}
@@ -0,0 +1,66 @@
+package tradingview
+
+import (
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// reconnect dials the socket again until it succeeds or the retry policy gives up.
+// The quote sessions are recreated and all the symbols previously added are subscribed again.
+// lastDataAt is when the last data was received before losing the connection, used to report the gap.
+func (s *Socket) reconnect(lastDataAt time.Time) {
+	attempt := 1
+	for ; ; attempt++ {
+		delay, retry := s.reconnectPolicy.NextDelay(attempt)
+		if !retry {
+			break
+		}
+
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		if s.isClosed() {
+			return
+		}
+
+		if s.circuitBreaker != nil {
+			select {
+			case <-s.ctx.Done():
+				return
+			case <-time.After(s.circuitBreaker.remainingCooldown()):
+			}
+		}
+
+		if s.connect() == nil {
+			atomic.AddInt64(&s.reconnectCount, 1)
+			go s.connectionLoop()
+			if s.onGap != nil {
+				s.onGap(&Gap{From: lastDataAt, To: time.Now(), Symbols: s.getSymbols()})
+			}
+			return
+		}
+		if s.ctx.Err() != nil {
+			return
+		}
+	}
+
+	s.OnErrorCallback(
+		errors.New("could not reconnect after "+strconv.Itoa(attempt-1)+" attempts"),
+		ReconnectErrorContext,
+	)
+}
+
+func (s *Socket) resubscribe() (err error) {
+	for _, qs := range s.getQuoteSessions() {
+		err = qs.resubscribe()
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
@@ -0,0 +1,317 @@
+package tradingview
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ReplaySession - Bar replay of a symbol and timeframe from a point in the past, as the replay mode of the website.
+// The candles are delivered to the callback of its chart session as if they were live, one step at a time.
+type ReplaySession struct {
+	socket *Socket
+	chart  *ChartSession
+
+	id string
+	// timeframe is the resolution the replay steps in, the chart can have the same one or a bigger one
+	timeframe Timeframe
+	// point is the time the replay has reached, where it starts again after a reconnection
+	point    time.Time
+	interval time.Duration
+	playing  bool
+	mutex    sync.RWMutex
+
+	done     chan struct{}
+	doneOnce sync.Once
+}
+
+// NewReplaySession creates a replay of the symbol in the given timeframe starting at from. The chart loads the barCount
+// candles before that point and then nothing else happens until Step or Start are called.
+func (s *Socket) NewReplaySession(
+	symbol string,
+	timeframe Timeframe,
+	from time.Time,
+	barCount int,
+	onCandle OnCandleCallback,
+) (session *ReplaySession, err error) {
+	if symbol == "" {
+		return nil, errors.New("the symbol of the replay can't be empty")
+	}
+	err = timeframe.Validate()
+	if err != nil {
+		return
+	}
+	if from.IsZero() || from.After(time.Now()) {
+		return nil, errors.New("the replay must start in the past")
+	}
+	if barCount <= 0 {
+		return nil, errors.New("the number of bars of the replay must be positive")
+	}
+	if onCandle == nil {
+		return nil, errors.New("the candle callback of the replay can't be nil")
+	}
+
+	rs := &ReplaySession{
+		socket:    s,
+		timeframe: timeframe,
+		point:     from,
+		done:      make(chan struct{}),
+	}
+	rs.generateID()
+
+	// The replay resolves the symbol as its chart, which is created on the server once the replay exists
+	rs.chart, err = s.newChartSession(symbol, timeframe, barCount, onCandle)
+	if err != nil {
+		return
+	}
+	rs.chart.replay = rs
+
+	s.registerReplaySession(rs)
+	err = rs.sendCreateMessages()
+	if err != nil {
+		s.unregisterReplaySession(rs)
+		return
+	}
+
+	err = s.createChartSession(rs.chart)
+	if err != nil {
+		rs.Close()
+		return
+	}
+
+	session = rs
+	return
+}
+
+// SessionID ...
+func (rs *ReplaySession) SessionID() string {
+	rs.mutex.RLock()
+	defer rs.mutex.RUnlock()
+
+	return rs.id
+}
+
+// Point returns the time the replay has reached
+func (rs *ReplaySession) Point() time.Time {
+	rs.mutex.RLock()
+	defer rs.mutex.RUnlock()
+
+	return rs.point
+}
+
+// Done is closed when the replay reaches the end of the data
+func (rs *ReplaySession) Done() <-chan struct{} {
+	return rs.done
+}
+
+// Step moves the replay forward the given number of bars
+func (rs *ReplaySession) Step(bars int) (err error) {
+	if bars <= 0 {
+		return errors.New("the number of bars to step must be positive")
+	}
+
+	return rs.socket.sendSocketMessage(
+		getSocketMessage("replay_step", []interface{}{rs.SessionID(), "req_replay_step", bars}),
+	)
+}
+
+// Start plays the replay, moving it forward one bar every interval
+func (rs *ReplaySession) Start(interval time.Duration) (err error) {
+	if interval < time.Millisecond {
+		return errors.New("the interval of the replay must be at least a millisecond")
+	}
+
+	err = rs.sendStart(interval)
+	if err != nil {
+		return
+	}
+
+	rs.mutex.Lock()
+	rs.interval = interval
+	rs.playing = true
+	rs.mutex.Unlock()
+	return
+}
+
+// SetSpeed changes the interval between the bars, while playing or paused, and it's kept for the next Start
+func (rs *ReplaySession) SetSpeed(interval time.Duration) (err error) {
+	if interval < time.Millisecond {
+		return errors.New("the interval of the replay must be at least a millisecond")
+	}
+
+	err = rs.socket.sendSocketMessage(
+		getSocketMessage("replay_set_speed", []interface{}{
+			rs.SessionID(), "req_replay_set_speed", int64(interval / time.Millisecond),
+		}),
+	)
+	if err != nil {
+		return
+	}
+
+	rs.mutex.Lock()
+	rs.interval = interval
+	rs.mutex.Unlock()
+	return
+}
+
+// Stop pauses the replay, Step and Start can be used again to continue it
+func (rs *ReplaySession) Stop() (err error) {
+	err = rs.socket.sendSocketMessage(
+		getSocketMessage("replay_stop", []string{rs.SessionID(), "req_replay_stop"}),
+	)
+	if err != nil {
+		return
+	}
+
+	rs.mutex.Lock()
+	rs.playing = false
+	rs.mutex.Unlock()
+	return
+}
+
+// Reset moves the replay back (or forward) to the given point, without playing it
+func (rs *ReplaySession) Reset(from time.Time) (err error) {
+	if from.IsZero() || from.After(time.Now()) {
+		return errors.New("the replay must start in the past")
+	}
+
+	err = rs.socket.sendSocketMessage(
+		getSocketMessage("replay_reset", []interface{}{rs.SessionID(), "req_replay_reset", from.Unix()}),
+	)
+	if err != nil {
+		return
+	}
+
+	rs.mutex.Lock()
+	rs.point = from
+	rs.playing = false
+	rs.mutex.Unlock()
+	return
+}
+
+// Close deletes the replay and its chart session from the server
+func (rs *ReplaySession) Close() (err error) {
+	if rs.chart != nil {
+		err = rs.chart.Close()
+	}
+
+	rs.socket.unregisterReplaySession(rs)
+	closeErr := rs.socket.sendSocketMessage(
+		getSocketMessage("replay_delete_session", []string{rs.SessionID()}),
+	)
+	if err == nil {
+		err = closeErr
+	}
+	return
+}
+
+func (rs *ReplaySession) generateID() {
+	rs.mutex.Lock()
+	defer rs.mutex.Unlock()
+
+	rs.id = ReplaySessionPrefix + GetRandomString(rs.socket.sessionIDLength)
+}
+
+// sendCreateMessages creates the replay at the point it has reached, playing it again if it was playing
+func (rs *ReplaySession) sendCreateMessages() (err error) {
+	rs.mutex.RLock()
+	sessionID, point, interval, playing := rs.id, rs.point, rs.interval, rs.playing
+	rs.mutex.RUnlock()
+
+	symbol, err := json.Marshal(rs.chart.getResolvedSymbol())
+	if err != nil {
+		return
+	}
+
+	messages := []*SocketMessage{
+		getSocketMessage("replay_create_session", []string{sessionID}),
+		getSocketMessage("replay_add_series", []interface{}{
+			sessionID, "req_replay_addseries", "=" + string(symbol), string(rs.timeframe),
+		}),
+		getSocketMessage("replay_reset", []interface{}{sessionID, "req_replay_reset", point.Unix()}),
+	}
+	for _, msg := range messages {
+		err = rs.socket.sendSocketMessage(msg)
+		if err != nil {
+			return
+		}
+	}
+
+	if playing {
+		err = rs.sendStart(interval)
+	}
+	return
+}
+
+func (rs *ReplaySession) sendStart(interval time.Duration) error {
+	return rs.socket.sendSocketMessage(
+		getSocketMessage("replay_start", []interface{}{
+			rs.SessionID(), "req_replay_start", int64(interval / time.Millisecond),
+		}),
+	)
+}
+
+// handleMessage processes the messages sent by the server to the replay session
+func (rs *ReplaySession) handleMessage(message string, payload []interface{}) {
+	switch message {
+	case "replay_point":
+		if point, ok := payload[len(payload)-1].(float64); ok {
+			rs.mutex.Lock()
+			rs.point = time.Unix(int64(point), 0)
+			rs.mutex.Unlock()
+		}
+	case "replay_data_end":
+		rs.mutex.Lock()
+		rs.playing = false
+		rs.mutex.Unlock()
+		rs.doneOnce.Do(func() { close(rs.done) })
+	case "replay_error", "critical_error":
+		raw, _ := json.Marshal(payload)
+		rs.socket.OnErrorCallback(errors.New(message+" -> "+string(raw)), ReplaySessionErrorContext)
+	}
+}
+
+func (s *Socket) registerReplaySession(rs *ReplaySession) {
+	s.replaySessionsMutex.Lock()
+	defer s.replaySessionsMutex.Unlock()
+
+	if s.replaySessions == nil {
+		s.replaySessions = map[string]*ReplaySession{}
+	}
+	s.replaySessions[rs.SessionID()] = rs
+}
+
+func (s *Socket) unregisterReplaySession(rs *ReplaySession) {
+	s.replaySessionsMutex.Lock()
+	defer s.replaySessionsMutex.Unlock()
+
+	delete(s.replaySessions, rs.SessionID())
+}
+
+func (s *Socket) getReplaySessions() []*ReplaySession {
+	s.replaySessionsMutex.RLock()
+	defer s.replaySessionsMutex.RUnlock()
+
+	sessions := make([]*ReplaySession, 0, len(s.replaySessions))
+	for _, rs := range s.replaySessions {
+		sessions = append(sessions, rs)
+	}
+	return sessions
+}
+
+func (s *Socket) renewReplaySessionID(rs *ReplaySession) {
+	s.replaySessionsMutex.Lock()
+	defer s.replaySessionsMutex.Unlock()
+
+	delete(s.replaySessions, rs.SessionID())
+	rs.generateID()
+	s.replaySessions[rs.SessionID()] = rs
+}
+
+func (s *Socket) getReplaySession(sessionID string) *ReplaySession {
+	s.replaySessionsMutex.RLock()
+	defer s.replaySessionsMutex.RUnlock()
+
+	return s.replaySessions[sessionID]
+}
@@ -0,0 +1,107 @@
+package tradingview
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// RetryPolicy decides how long to wait before every retry, and when to give up
+type RetryPolicy interface {
+	// NextDelay returns the wait before the given attempt (the first one is 1), or false when there must be no more attempts.
+	// Attempt 1 always starts a new sequence of retries.
+	NextDelay(attempt int) (delay time.Duration, retry bool)
+}
+
+// ConstantRetryPolicy waits the same delay before every attempt. A MaxAttempts of 0 means no limit.
+type ConstantRetryPolicy struct {
+	Delay       time.Duration
+	MaxAttempts int
+}
+
+// NextDelay ...
+func (p *ConstantRetryPolicy) NextDelay(attempt int) (time.Duration, bool) {
+	if p.MaxAttempts > 0 && attempt > p.MaxAttempts {
+		return 0, false
+	}
+	return p.Delay, true
+}
+
+// ExponentialRetryPolicy multiplies the delay by Multiplier (2 if not set) after every attempt, up to MaxDelay.
+// A MaxAttempts of 0 means no limit.
+type ExponentialRetryPolicy struct {
+	InitialDelay time.Duration
+	MaxDelay     time.Duration
+	Multiplier   float64
+	MaxAttempts  int
+}
+
+// NextDelay ...
+func (p *ExponentialRetryPolicy) NextDelay(attempt int) (time.Duration, bool) {
+	if p.MaxAttempts > 0 && attempt > p.MaxAttempts {
+		return 0, false
+	}
+
+	multiplier := p.Multiplier
+	if multiplier <= 1 {
+		multiplier = 2
+	}
+
+	delay := float64(p.InitialDelay)
+	for i := 1; i < attempt; i++ {
+		delay *= multiplier
+		if p.MaxDelay > 0 && delay >= float64(p.MaxDelay) {
+			break
+		}
+	}
+	if p.MaxDelay > 0 && delay > float64(p.MaxDelay) {
+		delay = float64(p.MaxDelay)
+	}
+	return time.Duration(delay), true
+}
+
+// DecorrelatedJitterRetryPolicy picks every delay randomly between BaseDelay and three times the previous delay,
+// capped at MaxDelay. The randomness prevents lots of clients from reconnecting at the same time after an outage.
+// A MaxAttempts of 0 means no limit.
+type DecorrelatedJitterRetryPolicy struct {
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+	MaxAttempts int
+
+	mutex     sync.Mutex
+	lastDelay time.Duration
+	random    *rand.Rand
+}
+
+// NextDelay ...
+func (p *DecorrelatedJitterRetryPolicy) NextDelay(attempt int) (time.Duration, bool) {
+	if p.MaxAttempts > 0 && attempt > p.MaxAttempts {
+		return 0, false
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.random == nil {
+		p.random = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	if attempt <= 1 || p.lastDelay < p.BaseDelay {
+		p.lastDelay = p.BaseDelay
+	}
+
+	delay := p.BaseDelay
+	if upper := int64(p.lastDelay*3 - p.BaseDelay); upper > 0 {
+		delay += time.Duration(p.random.Int63n(upper))
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	p.lastDelay = delay
+	return delay, true
+}
+
+// isResendableError tells if a message that failed to be sent is still valid and can be sent again later
+func isResendableError(err error) bool {
+	return err == ErrWriteQueueFull || err == ErrMessageDropped || err == ErrCircuitOpen
+}
@@ -0,0 +1,39 @@
+package tradingview
+
+// sessionMessageHandler is implemented by the sessions that handle their own messages, as the chart and replay sessions,
+// while the quote sessions go through the quote data flow
+type sessionMessageHandler interface {
+	handleMessage(message string, payload []interface{})
+}
+
+// routeSessionMessage delivers the message to the chart or replay session of its session id, the first item of the payload.
+// All the sessions share the connection, so the messages are handled in the order they were read.
+func (s *Socket) routeSessionMessage(message string, payload interface{}, ticket uint64) (handled bool) {
+	p, _ := payload.([]interface{})
+	if len(p) == 0 {
+		return
+	}
+
+	sessionID, _ := p[0].(string)
+	handler := s.getSessionMessageHandler(sessionID)
+	if handler == nil {
+		return
+	}
+
+	s.sequencer.wait(ticket)
+	handler.handleMessage(message, p)
+	return true
+}
+
+func (s *Socket) getSessionMessageHandler(sessionID string) sessionMessageHandler {
+	if sessionID == "" {
+		return nil
+	}
+	if cs := s.getChartSession(sessionID); cs != nil {
+		return cs
+	}
+	if rs := s.getReplaySession(sessionID); rs != nil {
+		return rs
+	}
+	return nil
+}
@@ -0,0 +1,351 @@
+package tradingview
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+// ScannerURL ...
+const ScannerURL = "https://scanner.tradingview.com/"
+
+// scannerPageSize is the number of symbols requested in every page of the scan
+const scannerPageSize = 1000
+
+// ScreenerMarketAmerica - Stocks of the US exchanges
+const ScreenerMarketAmerica = "america"
+
+// ScreenerMarketCrypto - Crypto pairs of the crypto exchanges
+const ScreenerMarketCrypto = "crypto"
+
+// ScreenerMarketCoin - Crypto coins, by their market cap instead of by exchange
+const ScreenerMarketCoin = "coin"
+
+// ScreenerMarketForex ...
+const ScreenerMarketForex = "forex"
+
+// ScreenerMarketFutures ...
+const ScreenerMarketFutures = "futures"
+
+// ScreenerMarketCFD ...
+const ScreenerMarketCFD = "cfd"
+
+// ScreenerMarketBonds ...
+const ScreenerMarketBonds = "bond"
+
+// The stocks of the rest of the countries have their own markets, named as the country: "germany", "uk", "japan", "india"...
+
+// ScreenerColumnName - Ticker of the symbol, without the exchange
+const ScreenerColumnName = "name"
+
+// ScreenerColumnDescription ...
+const ScreenerColumnDescription = "description"
+
+// ScreenerColumnExchange ...
+const ScreenerColumnExchange = "exchange"
+
+// ScreenerColumnType - stock, fund, dr, crypto...
+const ScreenerColumnType = "type"
+
+// ScreenerColumnClose - Last price
+const ScreenerColumnClose = "close"
+
+// ScreenerColumnChange - Change percent of the day
+const ScreenerColumnChange = "change"
+
+// ScreenerColumnChangeAbs - Change of the day
+const ScreenerColumnChangeAbs = "change_abs"
+
+// ScreenerColumnVolume - Volume of the day
+const ScreenerColumnVolume = "volume"
+
+// ScreenerColumnRelativeVolume - Volume of the day relative to the average of the last 10 days
+const ScreenerColumnRelativeVolume = "relative_volume_10d_calc"
+
+// ScreenerColumnMarketCap ...
+const ScreenerColumnMarketCap = "market_cap_basic"
+
+// ScreenerColumnPriceEarnings ...
+const ScreenerColumnPriceEarnings = "price_earnings_ttm"
+
+// ScreenerColumnSector ...
+const ScreenerColumnSector = "sector"
+
+// ScreenerColumnIndustry ...
+const ScreenerColumnIndustry = "industry"
+
+// ScreenerColumnRSI - RSI(14) of the daily candles
+const ScreenerColumnRSI = "RSI"
+
+// ScreenerColumnCurrency ...
+const ScreenerColumnCurrency = "currency"
+
+// FilterOperation - Comparison of a screener filter
+type FilterOperation string
+
+// FilterGreater ...
+const FilterGreater FilterOperation = "greater"
+
+// FilterGreaterOrEqual ...
+const FilterGreaterOrEqual FilterOperation = "egreater"
+
+// FilterLess ...
+const FilterLess FilterOperation = "less"
+
+// FilterLessOrEqual ...
+const FilterLessOrEqual FilterOperation = "eless"
+
+// FilterEqual ...
+const FilterEqual FilterOperation = "equal"
+
+// FilterNotEqual ...
+const FilterNotEqual FilterOperation = "nequal"
+
+// FilterInRange - The value is a []interface{}{min, max}
+const FilterInRange FilterOperation = "in_range"
+
+// FilterNotInRange - The value is a []interface{}{min, max}
+const FilterNotInRange FilterOperation = "not_in_range"
+
+// FilterMatch - The text contains the value, without case
+const FilterMatch FilterOperation = "match"
+
+// FilterCrosses - The column crossed the value, or the other column given as value, in the last candle
+const FilterCrosses FilterOperation = "crosses"
+
+// FilterCrossesAbove ...
+const FilterCrossesAbove FilterOperation = "crosses_above"
+
+// FilterCrossesBelow ...
+const FilterCrossesBelow FilterOperation = "crosses_below"
+
+// FilterHasAnyOf - The column has any of the values of a []string
+const FilterHasAnyOf FilterOperation = "has"
+
+// FilterHasNoneOf - The column has none of the values of a []string
+const FilterHasNoneOf FilterOperation = "has_none_of"
+
+// FilterEmpty - The column doesn't have a value, the value of the filter is ignored
+const FilterEmpty FilterOperation = "empty"
+
+// FilterNotEmpty - The column has a value, the value of the filter is ignored
+const FilterNotEmpty FilterOperation = "nempty"
+
+// Screener - Query of the scanner of a market, as the screeners of the website.
+// The methods change the query and return the screener, to chain them:
+//
+//	rows, err := NewScreener(nil, ScreenerMarketAmerica).
+//		Columns(ScreenerColumnName, ScreenerColumnClose, ScreenerColumnMarketCap).
+//		Where(ScreenerColumnMarketCap, FilterGreater, 1e9).
+//		SortBy(ScreenerColumnMarketCap, true).
+//		Range(0, 50).
+//		Scan(ctx)
+type Screener struct {
+	client  *http.Client
+	market  string
+	request scanRequest
+}
+
+// ScreenerResult - Page of the results of a screener
+type ScreenerResult struct {
+	// TotalCount is the number of symbols that match the filters, in all the pages
+	TotalCount int
+	Rows       []*ScreenerRow
+}
+
+// ScreenerRow - Values of the columns of a symbol
+type ScreenerRow struct {
+	// Symbol has the exchange, as NASDAQ:AAPL
+	Symbol string
+	Values map[string]interface{}
+}
+
+type scanRequest struct {
+	Filter  []scanFilter `json:"filter"`
+	Symbols *scanSymbols `json:"symbols,omitempty"`
+	Columns []string     `json:"columns"`
+	Sort    *scanSort    `json:"sort,omitempty"`
+	Range   [2]int       `json:"range"`
+}
+
+type scanFilter struct {
+	Left      string          `json:"left"`
+	Operation FilterOperation `json:"operation"`
+	Right     interface{}     `json:"right"`
+}
+
+type scanSymbols struct {
+	Tickers []string `json:"tickers"`
+}
+
+type scanSort struct {
+	SortBy    string `json:"sortBy"`
+	SortOrder string `json:"sortOrder"`
+}
+
+type scanResponse struct {
+	TotalCount int `json:"totalCount"`
+	Data       []struct {
+		Symbol string        `json:"s"`
+		Values []interface{} `json:"d"`
+	} `json:"data"`
+}
+
+// NewScreener creates a screener of the market (ScreenerMarketAmerica, ScreenerMarketCrypto, "germany"...),
+// without filters and for the first 1000 symbols. The HTTP client can be nil to use http.DefaultClient.
+func NewScreener(client *http.Client, market string) *Screener {
+	return &Screener{
+		client:  client,
+		market:  market,
+		request: scanRequest{Filter: []scanFilter{}, Range: [2]int{0, scannerPageSize}},
+	}
+}
+
+// Columns adds the columns (ScreenerColumnClose, ScreenerColumnVolume...) to the values of the rows.
+// Any column of the scanner of the website can be used, with the name of its API ("EMA20", "Perf.W"...).
+func (sc *Screener) Columns(columns ...string) *Screener {
+	sc.request.Columns = append(sc.request.Columns, columns...)
+	return sc
+}
+
+// Where adds a filter, the symbols must match all of them
+func (sc *Screener) Where(column string, operation FilterOperation, value interface{}) *Screener {
+	sc.request.Filter = append(sc.request.Filter, scanFilter{Left: column, Operation: operation, Right: value})
+	return sc
+}
+
+// Tickers limits the scan to the symbols, given with their exchange (NASDAQ:AAPL)
+func (sc *Screener) Tickers(symbols ...string) *Screener {
+	if sc.request.Symbols == nil {
+		sc.request.Symbols = &scanSymbols{}
+	}
+	sc.request.Symbols.Tickers = append(sc.request.Symbols.Tickers, symbols...)
+	return sc
+}
+
+// SortBy sorts the rows by the column, ascending or descending
+func (sc *Screener) SortBy(column string, descending bool) *Screener {
+	sc.request.Sort = &scanSort{SortBy: column, SortOrder: "asc"}
+	if descending {
+		sc.request.Sort.SortOrder = "desc"
+	}
+	return sc
+}
+
+// Range sets the rows returned by Scan, from the index from up to to (not included)
+func (sc *Screener) Range(from int, to int) *Screener {
+	sc.request.Range = [2]int{from, to}
+	return sc
+}
+
+// Scan returns the rows of the range
+func (sc *Screener) Scan(ctx context.Context) (result *ScreenerResult, err error) {
+	err = sc.validate()
+	if err != nil {
+		return
+	}
+
+	return sc.scan(ctx, sc.request.Range)
+}
+
+// ScanAll returns all the rows that match the filters, ignoring the range. They're requested in pages of 1000 symbols.
+func (sc *Screener) ScanAll(ctx context.Context) (rows []*ScreenerRow, err error) {
+	err = sc.validate()
+	if err != nil {
+		return
+	}
+
+	for from := 0; ; from += scannerPageSize {
+		var result *ScreenerResult
+		result, err = sc.scan(ctx, [2]int{from, from + scannerPageSize})
+		if err != nil {
+			return
+		}
+
+		rows = append(rows, result.Rows...)
+		if len(result.Rows) < scannerPageSize || len(rows) >= result.TotalCount {
+			return
+		}
+	}
+}
+
+func (sc *Screener) validate() error {
+	if sc.market == "" {
+		return errors.New("the market of the screener can't be empty")
+	}
+	if len(sc.request.Columns) == 0 {
+		return errors.New("the screener must have at least one column")
+	}
+	if sc.request.Range[0] < 0 || sc.request.Range[1] <= sc.request.Range[0] {
+		return errors.New("invalid range of the screener")
+	}
+	return nil
+}
+
+func (sc *Screener) scan(ctx context.Context, rowsRange [2]int) (result *ScreenerResult, err error) {
+	request := sc.request
+	request.Range = rowsRange
+
+	res, err := scan(ctx, sc.client, sc.market, &request)
+	if err != nil {
+		return
+	}
+
+	result = &ScreenerResult{TotalCount: res.TotalCount}
+	for _, data := range res.Data {
+		row := &ScreenerRow{Symbol: data.Symbol, Values: map[string]interface{}{}}
+		for i, column := range request.Columns {
+			if i < len(data.Values) {
+				row.Values[column] = data.Values[i]
+			}
+		}
+		result.Rows = append(result.Rows, row)
+	}
+	return
+}
+
+func scan(ctx context.Context, client *http.Client, market string, request *scanRequest) (res *scanResponse, err error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return
+	}
+
+	req, err := newHTTPRequest(ctx, http.MethodPost, ScannerURL+market+"/scan", bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	_, err = doHTTPRequest(client, req, &res)
+	if err == nil && res == nil {
+		err = errors.New("empty response from the scanner")
+	}
+	return
+}
+
+// Float returns the value of a numeric column, ok is false when the row doesn't have it
+func (r *ScreenerRow) Float(column string) (value float64, ok bool) {
+	value, ok = r.Values[column].(float64)
+	return
+}
+
+// String returns the value of a text column, ok is false when the row doesn't have it
+func (r *ScreenerRow) String(column string) (value string, ok bool) {
+	value, ok = r.Values[column].(string)
+	return
+}
+
+// Decode copies the values of the row into the struct v points to, matching the columns
+// with the mapstructure tags of its fields:
+//
+//	type Stock struct {
+//		Close     float64 `mapstructure:"close"`
+//		MarketCap float64 `mapstructure:"market_cap_basic"`
+//	}
+func (r *ScreenerRow) Decode(v interface{}) error {
+	return mapstructure.Decode(r.Values, v)
+}
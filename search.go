@@ -0,0 +1,100 @@
+package tradingview
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// SymbolSearchURL ...
+const SymbolSearchURL = "https://symbol-search.tradingview.com/symbol_search/v3/"
+
+// SymbolType - Kind of symbol of the symbol search filter
+type SymbolType string
+
+// SymbolTypeStock ...
+const SymbolTypeStock SymbolType = "stock"
+
+// SymbolTypeFund - ETFs and the rest of the funds
+const SymbolTypeFund SymbolType = "funds"
+
+// SymbolTypeFutures ...
+const SymbolTypeFutures SymbolType = "futures"
+
+// SymbolTypeForex ...
+const SymbolTypeForex SymbolType = "forex"
+
+// SymbolTypeCrypto ...
+const SymbolTypeCrypto SymbolType = "crypto"
+
+// SymbolTypeIndex ...
+const SymbolTypeIndex SymbolType = "index"
+
+// SymbolTypeBond ...
+const SymbolTypeBond SymbolType = "bond"
+
+// SymbolTypeEconomic - Macro series of the ECONOMICS exchange, see GetEconomicSeries
+const SymbolTypeEconomic SymbolType = "economic"
+
+// SymbolSearchResult - Symbol found by SearchSymbols
+type SymbolSearchResult struct {
+	// Ticker is the symbol with its exchange (NASDAQ:AAPL), ready to be used with AddSymbol
+	Ticker      string `json:"-"`
+	Symbol      string `json:"symbol"`
+	Description string `json:"description"`
+	Type        string `json:"type"`
+	Exchange    string `json:"exchange"`
+	Prefix      string `json:"prefix"`
+	Currency    string `json:"currency_code"`
+	Country     string `json:"country"`
+}
+
+type symbolSearchResponse struct {
+	Symbols []*SymbolSearchResult `json:"symbols"`
+}
+
+// SearchSymbols returns the symbols whose ticker or description match the text, the most relevant first,
+// as the symbol search of the website. The exchange (NASDAQ, BINANCE...) and the type (SymbolTypeStock...)
+// filter the results, they can be empty to search in all of them.
+// The HTTP client can be nil to use http.DefaultClient.
+func SearchSymbols(
+	ctx context.Context,
+	client *http.Client,
+	text string,
+	exchange string,
+	symbolType SymbolType,
+) (results []*SymbolSearchResult, err error) {
+	query := url.Values{}
+	query.Set("text", text)
+	query.Set("hl", "0")
+	query.Set("lang", "en")
+	query.Set("domain", "production")
+	if exchange != "" {
+		query.Set("exchange", strings.ToUpper(exchange))
+	}
+	if symbolType != "" {
+		query.Set("search_type", string(symbolType))
+	}
+
+	req, err := newHTTPRequest(ctx, http.MethodGet, SymbolSearchURL+"?"+query.Encode(), nil)
+	if err != nil {
+		return
+	}
+
+	var res *symbolSearchResponse
+	_, err = doHTTPRequest(client, req, &res)
+	if err != nil || res == nil {
+		return
+	}
+
+	for _, result := range res.Symbols {
+		// Some exchanges are returned with a prefix different than the exchange name used in the symbols
+		if result.Prefix != "" {
+			result.Exchange = result.Prefix
+		}
+		result.Ticker = result.Exchange + ":" + result.Symbol
+		results = append(results, result)
+	}
+	return
+}
@@ -0,0 +1,50 @@
+package tradingview
+
+import "sync"
+
+// sequencer lets the packets, which are parsed concurrently, take turns in the order they were received.
+// The chart sessions need it since their updates only make sense in order.
+type sequencer struct {
+	next  uint64
+	last  uint64
+	mutex sync.Mutex
+	cond  *sync.Cond
+}
+
+func newSequencer() *sequencer {
+	q := &sequencer{}
+	q.cond = sync.NewCond(&q.mutex)
+	return q
+}
+
+// ticket returns the turn of a new packet
+func (q *sequencer) ticket() uint64 {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	ticket := q.last
+	q.last++
+	return ticket
+}
+
+// wait blocks until it's the turn of the ticket
+func (q *sequencer) wait(ticket uint64) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for q.next != ticket {
+		q.cond.Wait()
+	}
+}
+
+// done waits for the turn of the ticket, if it didn't already, and passes it to the next one
+func (q *sequencer) done(ticket uint64) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for q.next != ticket {
+		q.cond.Wait()
+	}
+	q.next++
+	q.cond.Broadcast()
+}
@@ -0,0 +1,71 @@
+package tradingview
+
+import (
+	"strings"
+	"sync/atomic"
+)
+
+// sessionInvalidatedReasons are the texts found in critical_error messages when the server
+// no longer recognizes the quote session
+var sessionInvalidatedReasons = []string{
+	"session_expired",
+	"invalid_session",
+	"no_session",
+	"session_not_found",
+}
+
+func isSessionInvalidatedMessage(message string, raw []byte) bool {
+	if message == "protocol_error" {
+		return true
+	}
+	if message != "critical_error" {
+		return false
+	}
+
+	for _, reason := range sessionInvalidatedReasons {
+		if strings.Contains(string(raw), reason) {
+			return true
+		}
+	}
+	return false
+}
+
+// renewSessions creates again, on the same connection, the quote session mentioned in the error message,
+// or all of them if none is mentioned. The new sessions have the same fields and symbols as the old ones.
+// If it fails, the connection is closed as with any other error, so the reconnection can take over.
+func (s *Socket) renewSessions(raw []byte) {
+	if !atomic.CompareAndSwapInt32(&s.renewing, 0, 1) {
+		return
+	}
+	defer atomic.StoreInt32(&s.renewing, 0)
+
+	sessions := s.getQuoteSessions()
+	for _, qs := range sessions {
+		if strings.Contains(string(raw), qs.SessionID()) {
+			sessions = []*QuoteSession{qs}
+			break
+		}
+	}
+
+	err := s.recreateQuoteSessions(sessions)
+	if err != nil && s.ctx.Err() == nil {
+		s.onError(err, RenewSessionErrorContext)
+	}
+}
+
+// recreateQuoteSessions creates the sessions again with new ids, the same fields and the same symbols
+func (s *Socket) recreateQuoteSessions(sessions []*QuoteSession) (err error) {
+	for _, qs := range sessions {
+		s.renewQuoteSessionID(qs)
+
+		err = qs.sendCreateMessages()
+		if err == nil {
+			err = qs.resubscribe()
+		}
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
@@ -0,0 +1,59 @@
+package tradingview
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+)
+
+// HomeURL ...
+const HomeURL = "https://www.tradingview.com/"
+
+// ErrSessionNotAuthenticated is returned when the session cookies don't belong to a signed in user
+var ErrSessionNotAuthenticated = errors.New("the session is not authenticated, the cookies may have expired")
+
+var authTokenRegexp = regexp.MustCompile(`"auth_token":"([^"]+)"`)
+
+// GetAuthTokenFromSession exchanges the sessionid (and sessionid_sign) cookies of a browser where you are signed in
+// for the auth token used by WithAuthToken / SetAuthToken. Useful for the accounts that get a captcha when
+// signing in with Login. sessionIDSign can be empty for the accounts that don't have that cookie.
+func GetAuthTokenFromSession(sessionID string, sessionIDSign string) (string, error) {
+	return GetAuthTokenFromSessionWithContext(context.Background(), nil, sessionID, sessionIDSign)
+}
+
+// GetAuthTokenFromSessionWithContext - Same as GetAuthTokenFromSession, with a context and a custom HTTP client (http.DefaultClient if nil)
+func GetAuthTokenFromSessionWithContext(
+	ctx context.Context,
+	client *http.Client,
+	sessionID string,
+	sessionIDSign string,
+) (authToken string, err error) {
+	if sessionID == "" {
+		return "", errors.New("the sessionid can't be empty")
+	}
+
+	req, err := newHTTPRequest(ctx, http.MethodGet, HomeURL, nil)
+	if err != nil {
+		return
+	}
+	addSessionCookies(req, sessionID, sessionIDSign)
+
+	_, body, err := readHTTPResponse(client, req)
+	if err != nil {
+		return
+	}
+
+	matches := authTokenRegexp.FindSubmatch(body)
+	if matches == nil {
+		return "", ErrSessionNotAuthenticated
+	}
+	return string(matches[1]), nil
+}
+
+func addSessionCookies(req *http.Request, sessionID string, sessionIDSign string) {
+	req.AddCookie(&http.Cookie{Name: "sessionid", Value: sessionID})
+	if sessionIDSign != "" {
+		req.AddCookie(&http.Cookie{Name: "sessionid_sign", Value: sessionIDSign})
+	}
+}
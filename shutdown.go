@@ -0,0 +1,50 @@
+package tradingview
+
+import (
+	"context"
+	"time"
+)
+
+// Shutdown stops dispatching new messages, waits for the callbacks already running to finish
+// (or for the context to expire) and then closes the socket
+func (s *Socket) Shutdown(ctx context.Context) (err error) {
+	s.dispatchMutex.Lock()
+	s.draining = true
+	s.dispatchMutex.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.inFlight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	closeErr := s.Close()
+	if err == nil {
+		err = closeErr
+	}
+	return
+}
+
+// dispatch parses the packet in a new goroutine, unless the socket is shutting down
+func (s *Socket) dispatch(packet []byte) {
+	s.dispatchMutex.Lock()
+	defer s.dispatchMutex.Unlock()
+
+	if s.draining {
+		return
+	}
+
+	receivedAt := time.Now()
+	ticket := s.sequencer.ticket()
+	s.inFlight.Add(1)
+	go func() {
+		defer s.inFlight.Done()
+		s.parsePacket(packet, receivedAt, ticket)
+	}()
+}
@@ -0,0 +1,101 @@
+package tradingview
+
+import (
+	"reflect"
+	"sort"
+)
+
+// UpdateType tells if an update has all the data of the symbol or only what changed
+type UpdateType string
+
+// UpdateSnapshot - First update of the symbol, with all the fields the server has for it
+const UpdateSnapshot UpdateType = "snapshot"
+
+// UpdateDelta - Only the fields in ChangedFields have been received, the rest are nil because they didn't change
+const UpdateDelta UpdateType = "delta"
+
+// GetLatest returns the current complete data of the symbol in the default quote session,
+// with all the updates received since it was added merged. ok is false if nothing has been received yet.
+func (s *Socket) GetLatest(symbol string) (data *QuoteData, ok bool) {
+	return s.quoteSession.GetLatest(symbol)
+}
+
+// GetLatest returns the current complete data of the symbol, with all the updates received since it was added merged.
+// ok is false if nothing has been received yet.
+func (qs *QuoteSession) GetLatest(symbol string) (data *QuoteData, ok bool) {
+	qs.mutex.RLock()
+	defer qs.mutex.RUnlock()
+
+	snapshot, ok := qs.snapshots[symbol]
+	if !ok {
+		return
+	}
+
+	data = copyQuoteData(snapshot)
+	return
+}
+
+// updateSnapshot merges the update into the snapshot of the symbol, and marks the update as the initial snapshot
+// if it's the first one received. qsd messages are deltas, so the fields not included in the update keep their previous value.
+func (qs *QuoteSession) updateSnapshot(symbol string, data *QuoteData) {
+	qs.mutex.Lock()
+	defer qs.mutex.Unlock()
+
+	snapshot, ok := qs.snapshots[symbol]
+	if !ok {
+		data.UpdateType = UpdateSnapshot
+	}
+	if _, isSubscribed := qs.symbols[symbol]; !isSubscribed {
+		return
+	}
+
+	if !ok {
+		snapshot = &QuoteData{}
+		qs.snapshots[symbol] = snapshot
+	}
+	mergeStruct(reflect.ValueOf(snapshot).Elem(), reflect.ValueOf(data).Elem())
+}
+
+// mergeStruct copies into dst the fields of src that are set. The pointers to structs are merged recursively.
+func mergeStruct(dst reflect.Value, src reflect.Value) {
+	for i := 0; i < src.NumField(); i++ {
+		srcField := src.Field(i)
+		dstField := dst.Field(i)
+		if srcField.IsZero() {
+			continue
+		}
+
+		if srcField.Kind() == reflect.Ptr {
+			value := reflect.New(srcField.Type().Elem())
+			if !dstField.IsNil() {
+				value.Elem().Set(dstField.Elem())
+			}
+			if srcField.Elem().Kind() == reflect.Struct {
+				mergeStruct(value.Elem(), srcField.Elem())
+			} else {
+				value.Elem().Set(srcField.Elem())
+			}
+			dstField.Set(value)
+			continue
+		}
+
+		dstField.Set(srcField)
+	}
+}
+
+// copyQuoteData returns a deep copy, so the snapshot can't be modified through the returned data
+func copyQuoteData(data *QuoteData) *QuoteData {
+	copied := &QuoteData{}
+	mergeStruct(reflect.ValueOf(copied).Elem(), reflect.ValueOf(data).Elem())
+	return copied
+}
+
+// getChangedFields returns the names of the fields included in the update, sorted
+func getChangedFields(values map[string]interface{}) []string {
+	fields := make([]string, 0, len(values))
+	for field := range values {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
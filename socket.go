@@ -1,10 +1,15 @@
 package tradingview
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
+	"net/url"
 	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/mitchellh/mapstructure"
@@ -12,24 +17,159 @@ import (
 
 // Socket ...
 type Socket struct {
+	// Accessed atomically, kept first so they are 64-bit aligned on 32-bit platforms
+	lastPongAt     int64
+	lastMessageAt  int64
+	lastQuoteAt    int64
+	reconnectCount int64
+	latency        int64
+
 	OnReceiveMarketDataCallback OnReceiveDataCallback
 	OnErrorCallback             OnErrorCallback
 
-	conn      *websocket.Conn
-	isClosed  bool
-	sessionID string
+	transport      Transport
+	transportMutex sync.RWMutex
+	connected      int32
+	closed         int32
+	closeMutex     sync.Mutex
+
+	quoteSession       *QuoteSession
+	quoteSessions      map[string]*QuoteSession
+	quoteSessionsMutex sync.RWMutex
+	renewing           int32
+
+	outgoing         chan *outgoingFrame
+	priorityOutgoing chan *outgoingFrame
+	writeErrors      chan error
+	queueSize        int
+	queuePolicy      QueuePolicy
+
+	parentCtx context.Context
+	ctx       context.Context
+	cancel    context.CancelFunc
+
+	inFlight      sync.WaitGroup
+	dispatchMutex sync.Mutex
+	draining      bool
+
+	reconnectPolicy RetryPolicy
+	resendPolicy    RetryPolicy
+
+	readTimeout  time.Duration
+	writeTimeout time.Duration
+	pingInterval time.Duration
+	pingTimeout  time.Duration
+
+	staleTimeout time.Duration
+	onStale      OnStaleConnectionCallback
+
+	onGap OnGapDetectedCallback
+
+	stateStore StateStore
+
+	onClose OnCloseCallback
+
+	onSymbolInfo   OnSymbolInfoCallback
+	onMarketStatus OnMarketStatusChangedCallback
+	onTradingHalt  OnTradingHaltCallback
+
+	baseCurrency string
+	converter    *currencyConverter
+
+	symbolCallbacks      map[string][]OnReceiveDataCallback
+	symbolCallbacksMutex sync.RWMutex
+
+	symbolInfos      map[string]*SymbolInfo
+	symbolInfosMutex sync.RWMutex
+
+	volumeDelta bool
+	spread      bool
+
+	historyCache HistoryCache
+
+	watchlists            map[string]*watchlistSubscription
+	watchlistsMutex       sync.Mutex
+	watchlistSyncInterval time.Duration
+
+	chartSessions      map[string]*ChartSession
+	chartSessionsMutex sync.RWMutex
+	sequencer          *sequencer
+
+	replaySessions      map[string]*ReplaySession
+	replaySessionsMutex sync.RWMutex
+
+	authToken         string
+	authMutex         sync.RWMutex
+	sessionCookie     string
+	sessionCookieSign string
+	tokenProvider     TokenProvider
+	refreshingToken   int32
+	paused            int32
+	credentials       *CredentialPool
+
+	circuitBreaker *circuitBreaker
+	rateLimiter    *tokenBucket
+
+	transportFactory TransportFactory
+	dialerFactory    DialerFactory
+	proxyURL         *url.URL
+	httpClient       *http.Client
+	httpClientOnce   sync.Once
+	headers          http.Header
+	endpoints        *endpointPool
+	endpoint         string
+	compression      bool
+	readLimit        int64
+	readBufferSize   int
+	writeBufferSize  int
+
+	language string
+	country  string
+	timezone string
+
+	sessionPrefix   string
+	sessionIDLength int
+
+	fields []string
 }
 
 // Connect - Connects and returns the trading view socket object
 func Connect(
 	onReceiveMarketDataCallback OnReceiveDataCallback,
 	onErrorCallback OnErrorCallback,
+	options ...Option,
 ) (socket SocketInterface, err error) {
-	socket = &Socket{
+	return ConnectWithContext(context.Background(), onReceiveMarketDataCallback, onErrorCallback, options...)
+}
+
+// ConnectWithContext - Same as Connect, but cancelling the context closes the connection,
+// stops the reconnection attempts and prevents any further callback from being called
+func ConnectWithContext(
+	ctx context.Context,
+	onReceiveMarketDataCallback OnReceiveDataCallback,
+	onErrorCallback OnErrorCallback,
+	options ...Option,
+) (socket SocketInterface, err error) {
+	s := &Socket{
 		OnReceiveMarketDataCallback: onReceiveMarketDataCallback,
 		OnErrorCallback:             onErrorCallback,
+		parentCtx:                   ctx,
+		endpoints:                   newEndpointPool([]string{DataEndpoint}),
+		queueSize:                   DefaultWriteQueueSize,
+		queuePolicy:                 QueueBlock,
+		sessionPrefix:               DefaultSessionPrefix,
+		sessionIDLength:             DefaultSessionIDLength,
+		sequencer:                   newSequencer(),
+	}
+
+	for _, option := range options {
+		err = option(s)
+		if err != nil {
+			return
+		}
 	}
 
+	socket = s
 	err = socket.Init()
 
 	return
@@ -37,57 +177,174 @@ func Connect(
 
 // Init connects to the tradingview web socket
 func (s *Socket) Init() (err error) {
-	s.isClosed = true
-	s.conn, _, err = (&websocket.Dialer{}).Dial("wss://data.tradingview.com/socket.io/websocket", getHeaders())
-	if err != nil {
-		s.onError(err, InitErrorContext)
-		return
+	s.setClosed(true)
+
+	if s.quoteSession == nil {
+		s.quoteSessions = map[string]*QuoteSession{}
+		s.quoteSession = s.newQuoteSession(nil, s.fields)
+		s.registerQuoteSession(s.quoteSession)
+		if s.baseCurrency != "" {
+			s.converter = s.newCurrencyConverter(s.baseCurrency)
+			s.registerQuoteSession(s.converter.session)
+		}
+
+		if s.stateStore != nil {
+			err = s.restoreState()
+			if err != nil {
+				s.OnErrorCallback(err, RestoreStateErrorContext)
+				return
+			}
+		}
 	}
 
-	err = s.checkFirstReceivedMessage()
-	if err != nil {
-		return
+	parentCtx := s.parentCtx
+	if parentCtx == nil {
+		parentCtx = context.Background()
+	}
+	s.ctx, s.cancel = context.WithCancel(parentCtx)
+	s.dispatchMutex.Lock()
+	s.draining = false
+	s.dispatchMutex.Unlock()
+
+	if s.tokenProvider != nil && s.authToken == "" {
+		var token string
+		token, err = s.tokenProvider(s.ctx)
+		if err != nil {
+			s.cancel()
+			s.OnErrorCallback(err, RefreshAuthTokenErrorContext)
+			return
+		}
+		s.authToken = token
 	}
-	s.generateSessionID()
 
-	err = s.sendConnectionSetupMessages()
+	s.outgoing = make(chan *outgoingFrame, s.queueSize)
+	s.priorityOutgoing = make(chan *outgoingFrame, 1)
+	s.writeErrors = make(chan error, 1)
+	go s.writeLoop()
+
+	err = s.connect()
 	if err != nil {
-		s.onError(err, ConnectionSetupMessagesErrorContext)
+		s.cancel()
 		return
 	}
 
-	s.isClosed = false
+	s.setClosed(false)
 	go s.connectionLoop()
+	go s.closeOnContextDone()
+	if s.staleTimeout > 0 {
+		go s.watchdog()
+	}
 
 	return
 }
 
-// Close ...
+// Close deletes the quote sessions and closes the connection.
+// It's safe to call it concurrently and more than once; only the first call has any effect.
 func (s *Socket) Close() (err error) {
-	s.isClosed = true
-	return s.conn.Close()
+	s.closeMutex.Lock()
+	defer s.closeMutex.Unlock()
+
+	transport := s.getTransport()
+	if s.isClosed() || transport == nil {
+		return
+	}
+	s.setClosed(true)
+
+	for _, qs := range s.getQuoteSessions() {
+		s.writeMessage(websocket.TextMessage, encodeSocketMessage(
+			getSocketMessage("quote_delete_session", []string{qs.SessionID()}),
+		))
+	}
+	for _, cs := range s.getChartSessions() {
+		s.writeMessage(websocket.TextMessage, encodeSocketMessage(
+			getSocketMessage("chart_delete_session", []string{cs.SessionID()}),
+		))
+	}
+	for _, rs := range s.getReplaySessions() {
+		s.writeMessage(websocket.TextMessage, encodeSocketMessage(
+			getSocketMessage("replay_delete_session", []string{rs.SessionID()}),
+		))
+	}
+
+	s.cancel()
+	s.setConnected(false)
+	err = transport.Close()
+	return
 }
 
-// AddSymbol ...
+// AddSymbol adds the symbol to the default quote session
 func (s *Socket) AddSymbol(symbol string) (err error) {
-	err = s.sendSocketMessage(
-		getSocketMessage("quote_add_symbols", []interface{}{s.sessionID, symbol, getFlags()}),
-	)
-	return
+	return s.quoteSession.AddSymbol(symbol)
 }
 
-// RemoveSymbol ...
+// RemoveSymbol removes the symbol from the default quote session
 func (s *Socket) RemoveSymbol(symbol string) (err error) {
-	err = s.sendSocketMessage(
-		getSocketMessage("quote_remove_symbols", []interface{}{s.sessionID, symbol}),
-	)
+	return s.quoteSession.RemoveSymbol(symbol)
+}
+
+// AddSymbols adds all the symbols to the default quote session with a single message
+func (s *Socket) AddSymbols(symbols []string) (err error) {
+	return s.quoteSession.AddSymbols(symbols)
+}
+
+// RemoveSymbols removes all the symbols from the default quote session with a single message
+func (s *Socket) RemoveSymbols(symbols []string) (err error) {
+	return s.quoteSession.RemoveSymbols(symbols)
+}
+
+// SetFields changes the fields requested by the default quote session on the live connection
+func (s *Socket) SetFields(fields ...string) (err error) {
+	return s.quoteSession.SetFields(fields...)
+}
+
+// getSymbols returns the symbols of all the quote sessions
+func (s *Socket) getSymbols() []string {
+	var symbols []string
+	for _, qs := range s.getQuoteSessions() {
+		symbols = append(symbols, qs.getSymbols()...)
+	}
+	return symbols
+}
+
+// connect dials the socket and sends all the messages needed to have working quote sessions,
+// including the subscriptions to the symbols added before a reconnection
+func (s *Socket) connect() (err error) {
+	var transport Transport
+	transport, err = s.dial()
+	if err != nil {
+		s.onError(err, InitErrorContext)
+		return
+	}
+	s.setTransport(transport)
+
+	s.setPongHandler()
+	atomic.StoreInt64(&s.lastMessageAt, time.Now().UnixNano())
+
+	err = s.checkFirstReceivedMessage()
+	if err != nil {
+		return
+	}
+
+	err = s.sendConnectionSetupMessages()
+	if err != nil {
+		s.onError(err, ConnectionSetupMessagesErrorContext)
+		return
+	}
+
+	err = s.resubscribe()
+	if err != nil {
+		s.onError(err, ResubscribeErrorContext)
+		return
+	}
+
+	s.setConnected(true)
 	return
 }
 
 func (s *Socket) checkFirstReceivedMessage() (err error) {
 	var msg []byte
 
-	_, msg, err = s.conn.ReadMessage()
+	_, msg, err = s.readMessage()
 	if err != nil {
 		s.onError(err, ReadFirstMessageErrorContext)
 		return
@@ -111,19 +368,39 @@ func (s *Socket) checkFirstReceivedMessage() (err error) {
 	return
 }
 
-func (s *Socket) generateSessionID() {
-	s.sessionID = "qs_" + GetRandomString(12)
-}
-
+// sendConnectionSetupMessages authenticates, sets the locale and creates again all the quote and chart sessions,
+// with new ids since the ones of a previous connection are no longer valid
 func (s *Socket) sendConnectionSetupMessages() (err error) {
-	messages := []*SocketMessage{
-		getSocketMessage("set_auth_token", []string{"unauthorized_user_token"}),
-		getSocketMessage("quote_create_session", []string{s.sessionID}),
-		getSocketMessage("quote_set_fields", []string{s.sessionID, "lp", "volume", "bid", "ask"}),
+	err = s.sendAuthToken()
+	if err != nil {
+		return
+	}
+
+	err = s.sendLocale()
+	if err != nil {
+		return
+	}
+
+	for _, qs := range s.getQuoteSessions() {
+		s.renewQuoteSessionID(qs)
+		err = qs.sendCreateMessages()
+		if err != nil {
+			return
+		}
 	}
 
-	for _, msg := range messages {
-		err = s.sendSocketMessage(msg)
+	// The replays go first, their chart sessions are linked to them
+	for _, rs := range s.getReplaySessions() {
+		s.renewReplaySessionID(rs)
+		err = rs.sendCreateMessages()
+		if err != nil {
+			return
+		}
+	}
+
+	for _, cs := range s.getChartSessions() {
+		s.renewChartSessionID(cs)
+		err = cs.sendCreateMessages()
 		if err != nil {
 			return
 		}
@@ -132,54 +409,159 @@ func (s *Socket) sendConnectionSetupMessages() (err error) {
 	return
 }
 
+// sendSocketMessage sends the message, sending it again according to the resend policy
+// while it fails because of the backpressure or the circuit breaker
 func (s *Socket) sendSocketMessage(p *SocketMessage) (err error) {
-	payload, _ := json.Marshal(p)
-	payloadWithHeader := "~m~" + strconv.Itoa(len(payload)) + "~m~" + string(payload)
+	err = s.trySendSocketMessage(p)
+	if s.resendPolicy == nil {
+		return
+	}
+
+	for attempt := 1; isResendableError(err); attempt++ {
+		delay, retry := s.resendPolicy.NextDelay(attempt)
+		if !retry {
+			return
+		}
+
+		select {
+		case <-s.ctx.Done():
+			return s.ctx.Err()
+		case <-time.After(delay):
+		}
+		err = s.trySendSocketMessage(p)
+	}
+
+	return
+}
+
+func (s *Socket) trySendSocketMessage(p *SocketMessage) (err error) {
+	if s.circuitBreaker != nil && !s.circuitBreaker.allow() {
+		return ErrCircuitOpen
+	}
 
-	err = s.conn.WriteMessage(websocket.TextMessage, []byte(payloadWithHeader))
+	if s.rateLimiter != nil {
+		err = s.rateLimiter.wait(s.ctx)
+		if err != nil {
+			return
+		}
+	}
+
+	payloadWithHeader := encodeSocketMessage(p)
+
+	err = s.writeMessage(websocket.TextMessage, payloadWithHeader)
 	if err != nil {
-		s.onError(err, SendMessageErrorContext+" - "+payloadWithHeader)
+		s.onError(err, SendMessageErrorContext+" - "+string(payloadWithHeader))
 		return
 	}
 	return
 }
 
+func (s *Socket) getTransport() Transport {
+	s.transportMutex.RLock()
+	defer s.transportMutex.RUnlock()
+
+	return s.transport
+}
+
+func (s *Socket) setTransport(transport Transport) {
+	s.transportMutex.Lock()
+	defer s.transportMutex.Unlock()
+
+	s.transport = transport
+}
+
+func (s *Socket) isClosed() bool {
+	return atomic.LoadInt32(&s.closed) == 1
+}
+
+func (s *Socket) setClosed(closed bool) {
+	var value int32
+	if closed {
+		value = 1
+	}
+	atomic.StoreInt32(&s.closed, value)
+}
+
+func (s *Socket) readMessage() (msgType int, msg []byte, err error) {
+	transport := s.getTransport()
+	if t, ok := transport.(DeadlineTransport); ok && s.readTimeout > 0 {
+		t.SetReadDeadline(time.Now().Add(s.readTimeout))
+	}
+
+	return transport.ReadFrame()
+}
+
+func (s *Socket) closeOnContextDone() {
+	<-s.ctx.Done()
+
+	s.closeMutex.Lock()
+	defer s.closeMutex.Unlock()
+
+	if s.isClosed() {
+		return
+	}
+	s.setClosed(true)
+	s.setConnected(false)
+	s.getTransport().Close()
+}
+
 func (s *Socket) connectionLoop() {
+	if s.pingInterval > 0 {
+		if transport, ok := s.getTransport().(PingTransport); ok {
+			go s.pingLoop(transport)
+		}
+	}
+
 	var readMsgError error
-	var writeKeepAliveMsgError error
 
-	for readMsgError == nil && writeKeepAliveMsgError == nil {
-		if s.isClosed {
+	for readMsgError == nil {
+		if s.isClosed() {
 			break
 		}
 
 		var msgType int
 		var msg []byte
-		msgType, msg, readMsgError = s.conn.ReadMessage()
-
-		go func(msgType int, msg []byte) {
-			if msgType != websocket.TextMessage {
-				return
-			}
+		msgType, msg, readMsgError = s.readMessage()
+		if readMsgError != nil || msgType != websocket.TextMessage {
+			continue
+		}
 
-			if isKeepAliveMsg(msg) {
-				writeKeepAliveMsgError = s.conn.WriteMessage(msgType, msg)
-				return
-			}
+		if isKeepAliveMsg(msg) {
+			s.queueKeepAliveMessage(msgType, msg)
+			continue
+		}
 
-			go s.parsePacket(msg)
-		}(msgType, msg)
+		atomic.StoreInt64(&s.lastMessageAt, time.Now().UnixNano())
+		s.dispatch(msg)
 	}
 
-	if readMsgError != nil {
-		s.onError(readMsgError, ReadMessageErrorContext)
+	if s.ctx.Err() != nil {
+		return
 	}
-	if writeKeepAliveMsgError != nil {
+
+	// If writing the keep alive echo failed, the writer closed the connection and that's why the read failed
+	select {
+	case writeKeepAliveMsgError := <-s.writeErrors:
 		s.onError(writeKeepAliveMsgError, SendKeepAliveMessageErrorContext)
+	default:
+		if closeErr, isCloseErr := readMsgError.(*CloseError); isCloseErr {
+			s.onServerClose(closeErr)
+		} else if _, isTooLarge := readMsgError.(*MessageTooLargeError); isTooLarge {
+			s.onError(readMsgError, MessageTooLargeErrorContext)
+		} else if readMsgError != nil {
+			s.onError(readMsgError, ReadMessageErrorContext)
+		}
+	}
+
+	if s.reconnectPolicy != nil && !s.isClosed() {
+		go s.reconnect(time.Unix(0, atomic.LoadInt64(&s.lastMessageAt)))
 	}
 }
 
-func (s *Socket) parsePacket(packet []byte) {
+func (s *Socket) parsePacket(packet []byte, receivedAt time.Time, ticket uint64) {
+	defer s.sequencer.done(ticket)
+
+	var sessionsArr []*QuoteSession
 	var symbolsArr []string
 	var dataArr []*QuoteData
 
@@ -195,11 +577,15 @@ func (s *Socket) parsePacket(packet []byte) {
 		payload := packet[index+headerLength : index+headerLength+payloadLength]
 		index = index + headerLength + len(payload)
 
-		symbol, data, err := s.parseJSON(payload)
+		session, symbol, data, err := s.parseJSON(payload, receivedAt, ticket)
+		if _, isSymbolError := err.(*SymbolError); isSymbolError || err == errMessageHandled {
+			continue
+		}
 		if err != nil {
 			break
 		}
 
+		sessionsArr = append(sessionsArr, session)
 		dataArr = append(dataArr, data)
 		symbolsArr = append(symbolsArr, symbol)
 	}
@@ -207,18 +593,29 @@ func (s *Socket) parsePacket(packet []byte) {
 	for i := 0; i < len(dataArr); i++ {
 		isDuplicate := false
 		for j := i + 1; j < len(dataArr); j++ {
-			if GetStringRepresentation(dataArr[i]) == GetStringRepresentation(dataArr[j]) {
+			if sessionsArr[i] == sessionsArr[j] && GetStringRepresentation(dataArr[i]) == GetStringRepresentation(dataArr[j]) {
 				isDuplicate = true
 				break
 			}
 		}
+		if s.ctx.Err() != nil {
+			return
+		}
 		if !isDuplicate {
-			s.OnReceiveMarketDataCallback(symbolsArr[i], dataArr[i])
+			atomic.StoreInt64(&s.lastQuoteAt, time.Now().UnixNano())
+			if callback := sessionsArr[i].getCallback(); callback != nil {
+				callback(symbolsArr[i], dataArr[i])
+			}
+			if sessionsArr[i] == s.quoteSession {
+				for _, callback := range s.getSymbolCallbacks(symbolsArr[i]) {
+					callback(symbolsArr[i], dataArr[i])
+				}
+			}
 		}
 	}
 }
 
-func (s *Socket) parseJSON(msg []byte) (symbol string, data *QuoteData, err error) {
+func (s *Socket) parseJSON(msg []byte, receivedAt time.Time, ticket uint64) (session *QuoteSession, symbol string, data *QuoteData, err error) {
 	var decodedMessage *SocketMessage
 
 	err = json.Unmarshal(msg, &decodedMessage)
@@ -227,7 +624,34 @@ func (s *Socket) parseJSON(msg []byte) (symbol string, data *QuoteData, err erro
 		return
 	}
 
+	if s.credentials != nil && isAuthTokenThrottledMessage(decodedMessage.Message, msg) {
+		go s.refreshAuthToken()
+		err = errors.New("the account was throttled by the server -> " + string(msg))
+		return
+	}
+
+	if s.tokenProvider != nil && isAuthTokenRejectedMessage(decodedMessage.Message, msg) {
+		go s.refreshAuthToken()
+		err = errors.New("the auth token was rejected by the server -> " + string(msg))
+		return
+	}
+
+	if isSessionInvalidatedMessage(decodedMessage.Message, msg) {
+		go s.renewSessions(msg)
+		err = errors.New("the quote session was invalidated by the server -> " + string(msg))
+		return
+	}
+
+	// The candles only make sense in order, so the previous packets are handled first
+	if s.routeSessionMessage(decodedMessage.Message, decodedMessage.Payload, ticket) {
+		err = errMessageHandled
+		return
+	}
+
 	if decodedMessage.Message == "critical_error" || decodedMessage.Message == "error" {
+		if s.circuitBreaker != nil {
+			s.circuitBreaker.recordError()
+		}
 		err = errors.New("Error -> " + string(msg))
 		s.onError(err, DecodedMessageHasErrorPropertyErrorContext)
 		return
@@ -251,6 +675,13 @@ func (s *Socket) parseJSON(msg []byte) (symbol string, data *QuoteData, err erro
 		return
 	}
 
+	sessionID, _ := p[0].(string)
+	session = s.getQuoteSession(sessionID)
+	if session == nil {
+		err = errors.New("ignored message - Unknown quote session " + sessionID)
+		return
+	}
+
 	var decodedQuoteMessage *QuoteMessage
 	err = mapstructure.Decode(p[1].(map[string]interface{}), &decodedQuoteMessage)
 	if err != nil {
@@ -258,19 +689,77 @@ func (s *Socket) parseJSON(msg []byte) (symbol string, data *QuoteData, err erro
 		return
 	}
 
+	if decodedQuoteMessage.Status == "error" && decodedQuoteMessage.Symbol != "" {
+		symbolErr := &SymbolError{Symbol: decodedQuoteMessage.Symbol, Message: decodedQuoteMessage.ErrorMessage}
+		session.onSymbolError(symbolErr)
+		err = symbolErr
+		return
+	}
+
 	if decodedQuoteMessage.Status != "ok" || decodedQuoteMessage.Symbol == "" || decodedQuoteMessage.Data == nil {
 		err = errors.New("There is something wrong with the payload - couldn't be parsed -> " + string(msg))
 		s.onError(err, FinalPayloadHasMissingPropertiesErrorContext)
 		return
 	}
+
+	values, _ := p[1].(map[string]interface{})["v"].(map[string]interface{})
+	decodedQuoteMessage.Data.Fundamentals, err = decodeFundamentals(values)
+	if err != nil {
+		s.onError(err, FinalPayloadCantBeParsedErrorContext+" - "+string(msg))
+		return
+	}
+
+	err = s.handleSymbolInfo(decodedQuoteMessage.Symbol, values)
+	if err != nil {
+		s.onError(err, FinalPayloadCantBeParsedErrorContext+" - "+string(msg))
+		return
+	}
+	if s.circuitBreaker != nil {
+		s.circuitBreaker.recordSuccess()
+	}
+
 	symbol = decodedQuoteMessage.Symbol
 	data = decodedQuoteMessage.Data
+	data.ReceivedAt = receivedAt
+	data.UpdateType = UpdateDelta
+	data.ChangedFields = getChangedFields(values)
+	if s.volumeDelta {
+		session.setVolumeDelta(symbol, data)
+	}
+	s.handleTradingHalt(session, symbol, data)
+	if s.converter != nil && session != s.converter.session {
+		s.converter.convert(symbol, data)
+	}
+	if s.spread {
+		s.setSpread(session, symbol, data)
+	}
+	// The trading session is set first, so the snapshot of GetLatest has it too
+	previous, changed := session.setTradingSession(symbol, data)
+	session.updateSnapshot(symbol, data)
+	session.resolveAcks(symbol, nil)
+	if changed {
+		s.handleTradingSessionChange(symbol, previous, data)
+	}
 	return
 }
 
+// onServerClose reports the close code and reason through the close callback if there's one,
+// otherwise through the error callback
+func (s *Socket) onServerClose(closeErr *CloseError) {
+	if s.onClose == nil {
+		s.onError(closeErr, ConnectionClosedByServerErrorContext)
+		return
+	}
+
+	s.setConnected(false)
+	s.getTransport().Close()
+	s.onClose(closeErr.Code, closeErr.Reason)
+}
+
 func (s *Socket) onError(err error, context string) {
-	if s.conn != nil {
-		s.conn.Close()
+	s.setConnected(false)
+	if transport := s.getTransport(); transport != nil {
+		transport.Close()
 	}
 	s.OnErrorCallback(err, context)
 }
@@ -282,6 +771,11 @@ func getSocketMessage(m string, p interface{}) *SocketMessage {
 	}
 }
 
+func encodeSocketMessage(p *SocketMessage) []byte {
+	payload, _ := json.Marshal(p)
+	return []byte("~m~" + strconv.Itoa(len(payload)) + "~m~" + string(payload))
+}
+
 func getFlags() *Flags {
 	return &Flags{
 		Flags: []string{"force_permission"},
@@ -327,7 +821,23 @@ func getHeaders() http.Header {
 	headers.Set("Host", "data.tradingview.com")
 	headers.Set("Origin", "https://www.tradingview.com")
 	headers.Set("Pragma", "no-cache")
-	headers.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/86.0.4240.193 Safari/537.36")
+	headers.Set("User-Agent", DefaultUserAgent)
+
+	return headers
+}
+
+// getHeaders returns the default headers with the ones configured through the options on top
+func (s *Socket) getHeaders(endpoint string) http.Header {
+	headers := getHeaders()
+	if u, err := url.Parse(endpoint); err == nil {
+		headers.Set("Host", u.Host)
+	}
+	for key, values := range s.headers {
+		headers.Del(key)
+		for _, value := range values {
+			headers.Add(key, value)
+		}
+	}
 
 	return headers
 }
@@ -0,0 +1,48 @@
+package tradingview
+
+import "math"
+
+// Spread - Difference between the ask and the bid of a symbol
+type Spread struct {
+	Absolute float64
+	// BasisPoints is relative to the mid price
+	BasisPoints float64
+	// Ticks is nil when the tick size of the symbol is unknown, see WithOnSymbolInfo
+	Ticks *float64
+}
+
+// setSpread fills the spread of the update when it includes the bid or the ask, using the last known value of the other one
+func (s *Socket) setSpread(session *QuoteSession, symbol string, data *QuoteData) {
+	if data.Bid == nil && data.Ask == nil {
+		return
+	}
+
+	bid, ask := data.Bid, data.Ask
+	if bid == nil || ask == nil {
+		latest, ok := session.GetLatest(symbol)
+		if !ok {
+			return
+		}
+		if bid == nil {
+			bid = latest.Bid
+		}
+		if ask == nil {
+			ask = latest.Ask
+		}
+	}
+	if bid == nil || ask == nil {
+		return
+	}
+
+	spread := &Spread{Absolute: *ask - *bid}
+	if mid := (*ask + *bid) / 2; mid > 0 {
+		spread.BasisPoints = spread.Absolute / mid * 10000
+	}
+	if info, ok := s.GetSymbolInfo(symbol); ok {
+		if tickSize := info.TickSize(); tickSize > 0 {
+			ticks := math.Round(spread.Absolute/tickSize*1000) / 1000
+			spread.Ticks = &ticks
+		}
+	}
+	data.Spread = spread
+}
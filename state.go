@@ -0,0 +1,96 @@
+package tradingview
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+)
+
+// SubscriptionState - Fields and symbols of the default quote session
+type SubscriptionState struct {
+	Fields  []string `json:"fields"`
+	Symbols []string `json:"symbols"`
+}
+
+// StateStore persists the subscription state, so a restarted process can subscribe again to the same symbols
+type StateStore interface {
+	// Load returns nil, without error, when nothing has been saved yet
+	Load() (*SubscriptionState, error)
+	Save(state *SubscriptionState) error
+}
+
+// FileStateStore - StateStore that keeps the state in a JSON file
+type FileStateStore struct {
+	path  string
+	mutex sync.Mutex
+}
+
+// NewFileStateStore ...
+func NewFileStateStore(path string) *FileStateStore {
+	return &FileStateStore{path: path}
+}
+
+// Load ...
+func (fs *FileStateStore) Load() (state *SubscriptionState, err error) {
+	fs.mutex.Lock()
+	defer fs.mutex.Unlock()
+
+	content, err := os.ReadFile(fs.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(content, &state)
+	return
+}
+
+// Save ...
+func (fs *FileStateStore) Save(state *SubscriptionState) (err error) {
+	fs.mutex.Lock()
+	defer fs.mutex.Unlock()
+
+	content, err := json.Marshal(state)
+	if err != nil {
+		return
+	}
+	return writeFileAtomic(fs.path, content)
+}
+
+// restoreState loads the saved fields and symbols into the default quote session, before connecting
+func (s *Socket) restoreState() (err error) {
+	state, err := s.stateStore.Load()
+	if err != nil || state == nil {
+		return
+	}
+
+	qs := s.quoteSession
+	qs.mutex.Lock()
+	defer qs.mutex.Unlock()
+
+	// The fields given with WithFields take precedence over the stored ones
+	if len(state.Fields) > 0 && len(s.fields) == 0 {
+		qs.fields = append([]string(nil), state.Fields...)
+	}
+	for _, symbol := range state.Symbols {
+		qs.symbols[symbol] = true
+	}
+	return
+}
+
+// saveState is called every time the subscriptions of the default quote session change
+func (s *Socket) saveState() {
+	if s.stateStore == nil {
+		return
+	}
+
+	err := s.stateStore.Save(&SubscriptionState{
+		Fields:  s.quoteSession.getFields(),
+		Symbols: s.quoteSession.getSymbols(),
+	})
+	if err != nil {
+		s.OnErrorCallback(err, SaveStateErrorContext)
+	}
+}
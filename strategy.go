@@ -0,0 +1,212 @@
+package tradingview
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// StrategyReport - Backtest report of a Pine strategy, as the strategy tester of the website
+type StrategyReport struct {
+	Currency string
+	// Trades are the closed trades, oldest first
+	Trades      []*StrategyTrade
+	Performance *StrategyPerformance
+	// Equity, DrawDown and BuyHold are the curves of the strategy, one value per trade
+	Equity          []float64
+	EquityPercent   []float64
+	DrawDown        []float64
+	DrawDownPercent []float64
+	BuyHold         []float64
+	BuyHoldPercent  []float64
+}
+
+// StrategyTrade - Trade of a strategy, from the entry to the exit order
+type StrategyTrade struct {
+	Entry      StrategyOrder
+	Exit       StrategyOrder
+	Long       bool
+	Quantity   float64
+	Profit     StrategyAmount
+	Cumulative StrategyAmount
+	RunUp      StrategyAmount
+	DrawDown   StrategyAmount
+}
+
+// StrategyOrder ...
+type StrategyOrder struct {
+	Name  string
+	Price float64
+	Time  time.Time
+}
+
+// StrategyAmount - Amount in the currency of the report and as a percentage
+type StrategyAmount struct {
+	Value   float64 `json:"v"`
+	Percent float64 `json:"p"`
+}
+
+// StrategyPerformance - Performance summary of a strategy, for all the trades and for the long and short ones
+type StrategyPerformance struct {
+	All                        *StrategyStats `json:"all"`
+	Long                       *StrategyStats `json:"long"`
+	Short                      *StrategyStats `json:"short"`
+	MaxStrategyDrawDown        float64        `json:"maxStrategyDrawDown"`
+	MaxStrategyDrawDownPercent float64        `json:"maxStrategyDrawDownPercent"`
+	BuyHoldReturn              float64        `json:"buyHoldReturn"`
+	BuyHoldReturnPercent       float64        `json:"buyHoldReturnPercent"`
+	SharpeRatio                float64        `json:"sharpeRatio"`
+	SortinoRatio               float64        `json:"sortinoRatio"`
+	OpenPL                     float64        `json:"openPL"`
+	OpenPLPercent              float64        `json:"openPLPercent"`
+}
+
+// StrategyStats ...
+type StrategyStats struct {
+	NetProfit           float64 `json:"netProfit"`
+	NetProfitPercent    float64 `json:"netProfitPercent"`
+	GrossProfit         float64 `json:"grossProfit"`
+	GrossLoss           float64 `json:"grossLoss"`
+	ProfitFactor        float64 `json:"profitFactor"`
+	CommissionPaid      float64 `json:"commissionPaid"`
+	TotalTrades         int     `json:"totalTrades"`
+	WinningTrades       int     `json:"numberOfWiningTrades"`
+	LosingTrades        int     `json:"numberOfLosingTrades"`
+	PercentProfitable   float64 `json:"percentProfitable"`
+	AvgTrade            float64 `json:"avgTrade"`
+	AvgTradePercent     float64 `json:"avgTradePercent"`
+	AvgWinningTrade     float64 `json:"avgWinTrade"`
+	AvgLosingTrade      float64 `json:"avgLosTrade"`
+	LargestWinningTrade float64 `json:"largestWinTrade"`
+	LargestLosingTrade  float64 `json:"largestLosTrade"`
+	MaxContractsHeld    float64 `json:"maxContractsHeld"`
+}
+
+// strategyReportData is the report as sent by the server, every update only has the parts that changed
+type strategyReportData struct {
+	Currency        string               `json:"currency"`
+	Trades          []*strategyTradeData `json:"trades"`
+	Performance     *StrategyPerformance `json:"performance"`
+	Equity          []float64            `json:"equity"`
+	EquityPercent   []float64            `json:"equityPercent"`
+	DrawDown        []float64            `json:"drawDown"`
+	DrawDownPercent []float64            `json:"drawDownPercent"`
+	BuyHold         []float64            `json:"buyHold"`
+	BuyHoldPercent  []float64            `json:"buyHoldPercent"`
+}
+
+type strategyTradeData struct {
+	Entry      strategyOrderData `json:"e"`
+	Exit       strategyOrderData `json:"x"`
+	Quantity   float64           `json:"q"`
+	Profit     StrategyAmount    `json:"tp"`
+	Cumulative StrategyAmount    `json:"cp"`
+	RunUp      StrategyAmount    `json:"rn"`
+	DrawDown   StrategyAmount    `json:"dd"`
+}
+
+type strategyOrderData struct {
+	Name  string  `json:"c"`
+	Type  string  `json:"tp"`
+	Price float64 `json:"p"`
+	// Time is in milliseconds
+	Time int64 `json:"tm"`
+}
+
+// AddPineStrategy adds the Pine strategy to the chart session, as AddPineScript, and calls onReport every time its
+// backtest report changes, with the whole report
+func (cs *ChartSession) AddPineStrategy(
+	script *PineScript,
+	inputs map[string]interface{},
+	onReport OnStrategyReportCallback,
+) (study *Study, err error) {
+	if script == nil || script.Text == "" {
+		return nil, errors.New("the Pine script must be loaded with GetPineScript")
+	}
+	if onReport == nil {
+		return nil, errors.New("the report callback of the strategy can't be nil")
+	}
+
+	return cs.addStudy(&Study{script: pineStudyScript, inputs: script.getStudyInputs(inputs), onReport: onReport})
+}
+
+// handleStrategyUpdate merges the report in the update of the study, {"report": {...}}, and delivers the whole report
+func (st *Study) handleStrategyUpdate(fields map[string]interface{}) (err error) {
+	data, err := decodeStudyData(fields)
+	if err != nil || data == nil {
+		return
+	}
+
+	var update struct {
+		Report json.RawMessage `json:"report"`
+	}
+	err = json.Unmarshal(data, &update)
+	if err != nil || len(update.Report) == 0 {
+		return
+	}
+
+	// The parts of the report that are not in the update keep their previous value
+	if st.report == nil {
+		st.report = &strategyReportData{}
+	}
+	err = json.Unmarshal(update.Report, st.report)
+	if err != nil {
+		return
+	}
+
+	st.onReport(st.chart.symbol, st.report.toStrategyReport())
+	return
+}
+
+func (r *strategyReportData) toStrategyReport() *StrategyReport {
+	// The next updates are decoded on the same curves and performance, the report gets a copy of them
+	report := &StrategyReport{
+		Currency:        r.Currency,
+		Equity:          append([]float64(nil), r.Equity...),
+		EquityPercent:   append([]float64(nil), r.EquityPercent...),
+		DrawDown:        append([]float64(nil), r.DrawDown...),
+		DrawDownPercent: append([]float64(nil), r.DrawDownPercent...),
+		BuyHold:         append([]float64(nil), r.BuyHold...),
+		BuyHoldPercent:  append([]float64(nil), r.BuyHoldPercent...),
+	}
+
+	if r.Performance != nil {
+		performance := *r.Performance
+		performance.All = copyStrategyStats(r.Performance.All)
+		performance.Long = copyStrategyStats(r.Performance.Long)
+		performance.Short = copyStrategyStats(r.Performance.Short)
+		report.Performance = &performance
+	}
+
+	// The server sends the last trade first
+	for i := len(r.Trades) - 1; i >= 0; i-- {
+		t := r.Trades[i]
+		report.Trades = append(report.Trades, &StrategyTrade{
+			Entry:      t.Entry.toStrategyOrder(),
+			Exit:       t.Exit.toStrategyOrder(),
+			Long:       len(t.Entry.Type) == 0 || t.Entry.Type[0] != 's',
+			Quantity:   t.Quantity,
+			Profit:     t.Profit,
+			Cumulative: t.Cumulative,
+			RunUp:      t.RunUp,
+			DrawDown:   t.DrawDown,
+		})
+	}
+	return report
+}
+
+func (o *strategyOrderData) toStrategyOrder() StrategyOrder {
+	return StrategyOrder{
+		Name:  o.Name,
+		Price: o.Price,
+		Time:  time.Unix(0, o.Time*int64(time.Millisecond)),
+	}
+}
+
+func copyStrategyStats(stats *StrategyStats) *StrategyStats {
+	if stats == nil {
+		return nil
+	}
+	copied := *stats
+	return &copied
+}
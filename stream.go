@@ -0,0 +1,92 @@
+package tradingview
+
+import (
+	"errors"
+	"sync"
+)
+
+// StreamBars loads the last barCount candles of the symbol in the given timeframe and then streams the live updates of the same series.
+// onHistory receives the history once, oldest first, and onCandle every update after it: the last candle of the history while it's
+// still forming, and then the new ones. The candles older than the last one delivered are dropped, so there are no duplicates, and
+// after a reconnection the history sent again by the server fills the candles missed while disconnected.
+func (s *Socket) StreamBars(
+	symbol string,
+	timeframe Timeframe,
+	barCount int,
+	onHistory OnHistoryCallback,
+	onCandle OnCandleCallback,
+	options ...ChartOption,
+) (session *ChartSession, err error) {
+	if symbol == "" {
+		return nil, errors.New("the symbol can't be empty")
+	}
+	err = timeframe.Validate()
+	if err != nil {
+		return
+	}
+	if barCount <= 0 {
+		return nil, errors.New("the number of bars must be positive")
+	}
+	if onHistory == nil || onCandle == nil {
+		return nil, errors.New("the history and candle callbacks can't be nil")
+	}
+
+	stream := &barStream{symbol: symbol, onHistory: onHistory, onCandle: onCandle, history: map[int64]*Candle{}}
+	cs, err := s.newChartSession(symbol, timeframe, barCount, stream.onUpdate, options...)
+	if err != nil {
+		return
+	}
+	cs.onLoaded = stream.onLoaded
+
+	err = s.createChartSession(cs)
+	if err != nil {
+		return
+	}
+
+	session = cs
+	return
+}
+
+// barStream buffers the candles until the history is complete, and then filters the live ones
+type barStream struct {
+	onHistory OnHistoryCallback
+	onCandle  OnCandleCallback
+
+	symbol  string
+	live    bool
+	history map[int64]*Candle
+	// last is the time of the last candle delivered
+	last  int64
+	mutex sync.Mutex
+}
+
+func (bs *barStream) onUpdate(symbol string, candle *Candle) {
+	bs.mutex.Lock()
+	if !bs.live {
+		// The updates received while loading replace the candles of the history with the same time
+		bs.history[candle.Time.Unix()] = candle
+		bs.mutex.Unlock()
+		return
+	}
+	if candle.Time.Unix() < bs.last {
+		bs.mutex.Unlock()
+		return
+	}
+	bs.last = candle.Time.Unix()
+	bs.mutex.Unlock()
+
+	bs.onCandle(symbol, candle)
+}
+
+func (bs *barStream) onLoaded() {
+	bs.mutex.Lock()
+	candles := sortCandles(bs.history)
+	if len(candles) > 0 {
+		bs.last = candles[len(candles)-1].Time.Unix()
+	}
+	bs.history = nil
+	bs.live = true
+	bs.mutex.Unlock()
+
+	bs.onHistory(bs.symbol, candles)
+}
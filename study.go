@@ -0,0 +1,252 @@
+package tradingview
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"strconv"
+	"time"
+)
+
+// StudyVolume ...
+const StudyVolume = "Volume@tv-basicstudies-1"
+
+// StudyRSI ...
+const StudyRSI = "RSI@tv-basicstudies-1"
+
+// StudyMACD ...
+const StudyMACD = "MACD@tv-basicstudies-1"
+
+// StudyBollingerBands ...
+const StudyBollingerBands = "BB@tv-basicstudies-1"
+
+// StudySMA ...
+const StudySMA = "MASimple@tv-basicstudies-1"
+
+// StudyEMA ...
+const StudyEMA = "MAExp@tv-basicstudies-1"
+
+// StudyVWAP ...
+const StudyVWAP = "VWAP@tv-basicstudies-1"
+
+// studyEmptyValue is the value sent for the bars where the study has no value, as the first ones of a moving average
+const studyEmptyValue = 1e100
+
+// StudyValue - Values of the plots of a study for a bar, in the order the study defines them (MACD, signal and histogram...)
+// The bars where a plot has no value have NaN.
+type StudyValue struct {
+	Time   time.Time
+	Values []float64
+}
+
+// Study - Indicator computed by the server on the series of a chart session, the values are the same as in the website
+type Study struct {
+	chart    *ChartSession
+	onValue  OnStudyValueCallback
+	onReport OnStrategyReportCallback
+	// report is the backtest report of the strategies, updated with every message
+	report *strategyReportData
+
+	onProfile   OnVolumeProfileCallback
+	profileRows map[int64]*VolumeProfileRow
+
+	id     string
+	script string
+	inputs map[string]interface{}
+}
+
+// AddStudy adds a built-in study (StudyRSI, StudyMACD...) to the chart session. The inputs are the ones of the study,
+// as {"in_0": 14} for the length of the RSI, and can be nil for the defaults. onValue receives the values of the
+// history first and then the live ones, along with the candles.
+func (cs *ChartSession) AddStudy(script string, inputs map[string]interface{}, onValue OnStudyValueCallback) (study *Study, err error) {
+	if script == "" {
+		return nil, errors.New("the script of the study can't be empty")
+	}
+	if onValue == nil {
+		return nil, errors.New("the value callback of the study can't be nil")
+	}
+
+	return cs.addStudy(&Study{script: script, inputs: inputs, onValue: onValue})
+}
+
+// addStudy creates the study, which must have its script, inputs and callbacks set
+func (cs *ChartSession) addStudy(st *Study) (study *Study, err error) {
+	if st.inputs == nil {
+		st.inputs = map[string]interface{}{}
+	}
+
+	// The ids are unique in the whole chart session, the studies of all the series are kept in the first one
+	root := cs.root()
+	root.mutex.Lock()
+	root.lastStudyID++
+	st.chart = cs
+	st.id = "st" + strconv.Itoa(root.lastStudyID)
+	if root.studies == nil {
+		root.studies = map[string]*Study{}
+	}
+	root.studies[st.id] = st
+	root.mutex.Unlock()
+
+	err = st.sendCreateMessage(cs.SessionID())
+	if err != nil {
+		cs.removeStudy(st)
+		return
+	}
+
+	study = st
+	return
+}
+
+// Remove removes the study from the chart session
+func (st *Study) Remove() (err error) {
+	st.chart.removeStudy(st)
+
+	return st.chart.socket.sendSocketMessage(
+		getSocketMessage("remove_study", []string{st.chart.SessionID(), st.id}),
+	)
+}
+
+func (st *Study) sendCreateMessage(sessionID string) error {
+	return st.chart.socket.sendSocketMessage(
+		getSocketMessage("create_study", []interface{}{sessionID, st.id, st.id, st.chart.seriesID(), st.script, st.inputs}),
+	)
+}
+
+func (cs *ChartSession) removeStudy(st *Study) {
+	root := cs.root()
+	root.mutex.Lock()
+	defer root.mutex.Unlock()
+
+	delete(root.studies, st.id)
+}
+
+func (cs *ChartSession) getStudies() []*Study {
+	cs.mutex.RLock()
+	defer cs.mutex.RUnlock()
+
+	studies := make([]*Study, 0, len(cs.studies))
+	for _, st := range cs.studies {
+		studies = append(studies, st)
+	}
+	return studies
+}
+
+func (cs *ChartSession) getStudy(id string) *Study {
+	cs.mutex.RLock()
+	defer cs.mutex.RUnlock()
+
+	return cs.studies[id]
+}
+
+// handleStudyUpdates delivers the values of the studies in a series update
+func (cs *ChartSession) handleStudyUpdates(updates map[string]interface{}) {
+	for id, update := range updates {
+		st := cs.getStudy(id)
+		if st == nil {
+			continue
+		}
+
+		fields, _ := update.(map[string]interface{})
+		if st.onReport != nil {
+			err := st.handleStrategyUpdate(fields)
+			if err != nil {
+				cs.socket.OnErrorCallback(err, StudyErrorContext)
+			}
+		}
+		if st.onProfile != nil {
+			err := st.handleVolumeProfileUpdate(fields)
+			if err != nil {
+				cs.socket.OnErrorCallback(err, StudyErrorContext)
+			}
+		}
+		if st.onValue == nil {
+			continue
+		}
+		for _, value := range decodeStudyValues(fields) {
+			st.onValue(st.chart.symbol, st.script, value)
+		}
+	}
+}
+
+// handleStudyError reports the errors of the studies as a *ChartError, {"m": "study_error", "p": [sessionID, studyID, ...]}
+func (cs *ChartSession) handleStudyError(payload []interface{}) {
+	symbol := cs.symbol
+	if len(payload) > 1 {
+		id, _ := payload[1].(string)
+		if st := cs.getStudy(id); st != nil {
+			symbol = st.chart.symbol
+		}
+	}
+	cs.socket.OnErrorCallback(newChartError("study_error", symbol, payload), StudyErrorContext)
+}
+
+// decodeStudyData returns the data of the study that is not a plot, as the reports of the strategies,
+// {"ns": {"d": "{...}"}}, nil when there's none
+func decodeStudyData(fields map[string]interface{}) (data []byte, err error) {
+	ns, _ := fields["ns"].(map[string]interface{})
+	d, _ := ns["d"].(string)
+	if d == "" {
+		return
+	}
+
+	var compressed struct {
+		DataCompressed string `json:"dataCompressed"`
+	}
+	err = json.Unmarshal([]byte(d), &compressed)
+	if err != nil || compressed.DataCompressed == "" {
+		return []byte(d), err
+	}
+	return decompressStudyData(compressed.DataCompressed)
+}
+
+// decompressStudyData decodes the big data, sent as a base64 zip with a single file
+func decompressStudyData(data string) (decompressed []byte, err error) {
+	raw, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(raw), int64(len(raw)))
+	if err != nil {
+		return
+	}
+	if len(archive.File) == 0 {
+		return nil, errors.New("the compressed study data is empty")
+	}
+
+	file, err := archive.File[0].Open()
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	return io.ReadAll(file)
+}
+
+// decodeStudyValues decodes the values of a study update, {"st": [{"i": 0, "v": [time, plot0, plot1...]}]}
+func decodeStudyValues(study map[string]interface{}) (values []*StudyValue) {
+	bars, _ := study["st"].([]interface{})
+	for _, bar := range bars {
+		fields, _ := bar.(map[string]interface{})
+		plots, _ := fields["v"].([]interface{})
+		if len(plots) < 1 {
+			continue
+		}
+
+		t, _ := plots[0].(float64)
+		value := &StudyValue{Time: time.Unix(int64(t), 0), Values: make([]float64, len(plots)-1)}
+		for i, plot := range plots[1:] {
+			number, ok := plot.(float64)
+			if !ok || math.Abs(number) >= studyEmptyValue {
+				number = math.NaN()
+			}
+			value.Values[i] = number
+		}
+		values = append(values, value)
+	}
+	return
+}
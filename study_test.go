@@ -0,0 +1,52 @@
+package tradingview
+
+import (
+	"testing"
+)
+
+func TestStudyValuesOfEverySeries(t *testing.T) {
+	s := &Socket{
+		sessionPrefix:   DefaultSessionPrefix,
+		sessionIDLength: DefaultSessionIDLength,
+		sequencer:       newSequencer(),
+	}
+	onCandle := func(symbol string, candle *Candle) {}
+
+	root, err := s.newChartSession("NASDAQ:AAPL", Timeframe1Day, 10, onCandle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	series, err := s.newChartSession("NASDAQ:MSFT", Timeframe1Day, 10, onCandle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	series.parent = root
+	series.index = 2
+	root.series = map[string]*ChartSession{series.seriesID(): series}
+
+	symbols := map[string]string{}
+	onValue := func(study string) OnStudyValueCallback {
+		return func(symbol string, script string, value *StudyValue) {
+			symbols[study] = symbol
+		}
+	}
+	root.studies = map[string]*Study{
+		"st1": {chart: root, id: "st1", script: StudyRSI, onValue: onValue("st1")},
+		"st2": {chart: series, id: "st2", script: StudyRSI, onValue: onValue("st2")},
+	}
+
+	root.handleMessage("du", []interface{}{
+		root.SessionID(),
+		map[string]interface{}{
+			"st1": map[string]interface{}{"st": []interface{}{map[string]interface{}{"v": []interface{}{1600000000.0, 50.0}}}},
+			"st2": map[string]interface{}{"st": []interface{}{map[string]interface{}{"v": []interface{}{1600000000.0, 60.0}}}},
+		},
+	})
+
+	if symbols["st1"] != "NASDAQ:AAPL" {
+		t.Fatalf("expected the values of st1 for NASDAQ:AAPL, got %q", symbols["st1"])
+	}
+	if symbols["st2"] != "NASDAQ:MSFT" {
+		t.Fatalf("expected the values of st2 for NASDAQ:MSFT, got %q", symbols["st2"])
+	}
+}
@@ -0,0 +1,39 @@
+package tradingview
+
+import "errors"
+
+// Subscribe adds the symbol to the default quote session and delivers its updates to the callback,
+// in addition to the data callback of the socket. Several callbacks can be subscribed to the same symbol.
+func (s *Socket) Subscribe(symbol string, callback OnReceiveDataCallback) (err error) {
+	if callback == nil {
+		return errors.New("the callback of the subscription can't be nil")
+	}
+
+	s.symbolCallbacksMutex.Lock()
+	if s.symbolCallbacks == nil {
+		s.symbolCallbacks = map[string][]OnReceiveDataCallback{}
+	}
+	s.symbolCallbacks[symbol] = append(s.symbolCallbacks[symbol], callback)
+	s.symbolCallbacksMutex.Unlock()
+
+	if s.quoteSession.IsSubscribed(symbol) {
+		return
+	}
+	return s.AddSymbol(symbol)
+}
+
+// Unsubscribe removes all the callbacks of the symbol and removes it from the default quote session
+func (s *Socket) Unsubscribe(symbol string) (err error) {
+	s.symbolCallbacksMutex.Lock()
+	delete(s.symbolCallbacks, symbol)
+	s.symbolCallbacksMutex.Unlock()
+
+	return s.RemoveSymbol(symbol)
+}
+
+func (s *Socket) getSymbolCallbacks(symbol string) []OnReceiveDataCallback {
+	s.symbolCallbacksMutex.RLock()
+	defer s.symbolCallbacksMutex.RUnlock()
+
+	return append([]OnReceiveDataCallback(nil), s.symbolCallbacks[symbol]...)
+}
@@ -0,0 +1,79 @@
+package tradingview
+
+import "sort"
+
+// ListSymbols returns the symbols subscribed in any of the quote sessions, sorted.
+// The exchange rates used by WithCurrencyConversion are not included.
+func (s *Socket) ListSymbols() []string {
+	unique := map[string]bool{}
+	for _, qs := range s.getQuoteSessions() {
+		if s.converter != nil && qs == s.converter.session {
+			continue
+		}
+		for _, symbol := range qs.getSymbols() {
+			unique[symbol] = true
+		}
+	}
+
+	return getSortedKeys(unique)
+}
+
+// IsSubscribed tells if the symbol is subscribed in any of the quote sessions
+func (s *Socket) IsSubscribed(symbol string) bool {
+	for _, qs := range s.getQuoteSessions() {
+		if s.converter != nil && qs == s.converter.session {
+			continue
+		}
+		if qs.IsSubscribed(symbol) {
+			return true
+		}
+	}
+	return false
+}
+
+// ListSymbols returns the symbols of the session, sorted
+func (qs *QuoteSession) ListSymbols() []string {
+	qs.mutex.RLock()
+	defer qs.mutex.RUnlock()
+
+	return getSortedKeys(qs.symbols)
+}
+
+// IsSubscribed ...
+func (qs *QuoteSession) IsSubscribed(symbol string) bool {
+	qs.mutex.RLock()
+	defer qs.mutex.RUnlock()
+
+	return qs.symbols[symbol]
+}
+
+// ListSymbols returns the symbols of all the sockets of the pool, sorted
+func (p *SocketPool) ListSymbols() []string {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	symbols := make([]string, 0, len(p.assignments))
+	for symbol := range p.assignments {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	return symbols
+}
+
+// IsSubscribed ...
+func (p *SocketPool) IsSubscribed(symbol string) bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	_, isAssigned := p.assignments[symbol]
+	return isAssigned
+}
+
+func getSortedKeys(values map[string]bool) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,84 @@
+package tradingview
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+var exchangeRegexp = regexp.MustCompile(`^[A-Z0-9_]+$`)
+var tickerRegexp = regexp.MustCompile(`^[A-Z0-9_.!&/\-]+$`)
+
+// continuousFuturesRegexp matches the continuous futures tickers, the root and the contract number (1 for the front one)
+var continuousFuturesRegexp = regexp.MustCompile(`^[A-Z0-9_.]+[0-9]+!$`)
+
+// Symbol - A symbol split in its exchange (or broker) and ticker
+type Symbol struct {
+	Exchange string
+	Ticker   string
+}
+
+// String returns the symbol in the EXCHANGE:TICKER format used by AddSymbol
+func (s Symbol) String() string {
+	return s.Exchange + ":" + s.Ticker
+}
+
+// ParseSymbol validates the EXCHANGE:TICKER format, ignoring the spaces around and the case
+func ParseSymbol(symbol string) (parsed Symbol, err error) {
+	parts := strings.Split(strings.ToUpper(strings.TrimSpace(symbol)), ":")
+	if len(parts) != 2 {
+		return Symbol{}, errors.New("the symbol " + symbol + " must have the EXCHANGE:TICKER format")
+	}
+
+	parsed = Symbol{Exchange: strings.TrimSpace(parts[0]), Ticker: strings.TrimSpace(parts[1])}
+	if !exchangeRegexp.MatchString(parsed.Exchange) {
+		return Symbol{}, errors.New("invalid exchange in the symbol " + symbol)
+	}
+	if !tickerRegexp.MatchString(parsed.Ticker) {
+		return Symbol{}, errors.New("invalid ticker in the symbol " + symbol)
+	}
+	return
+}
+
+// IsContinuousFutures tells if the symbol is a continuous futures contract, as CME_MINI:ES1! or NQ2!
+func IsContinuousFutures(symbol string) bool {
+	if i := strings.LastIndex(symbol, ":"); i >= 0 {
+		symbol = symbol[i+1:]
+	}
+	return continuousFuturesRegexp.MatchString(strings.ToUpper(symbol))
+}
+
+// NormalizeSymbol returns the symbol in the format expected by the server, " binance:btcusdt" becomes "BINANCE:BTCUSDT"
+func NormalizeSymbol(symbol string) (string, error) {
+	parsed, err := ParseSymbol(symbol)
+	if err != nil {
+		return "", err
+	}
+	return parsed.String(), nil
+}
+
+// ResolveSymbol normalizes the symbol, and when it has no exchange (AAPL) uses the symbol search
+// to find the most relevant one (NASDAQ:AAPL). The HTTP client can be nil to use http.DefaultClient.
+func ResolveSymbol(ctx context.Context, client *http.Client, symbol string) (string, error) {
+	if strings.Contains(symbol, ":") {
+		return NormalizeSymbol(symbol)
+	}
+
+	ticker := strings.ToUpper(strings.TrimSpace(symbol))
+	if !tickerRegexp.MatchString(ticker) {
+		return "", errors.New("invalid ticker " + symbol)
+	}
+
+	results, err := SearchSymbols(ctx, client, ticker, "", "")
+	if err != nil {
+		return "", err
+	}
+	for _, result := range results {
+		if strings.EqualFold(result.Symbol, ticker) && result.Exchange != "" {
+			return NormalizeSymbol(result.Ticker)
+		}
+	}
+	return "", errors.New("no symbol found for " + symbol)
+}
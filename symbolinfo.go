@@ -0,0 +1,91 @@
+package tradingview
+
+import "github.com/mitchellh/mapstructure"
+
+// SymbolInfoFields are the quote fields requested for the symbol info when WithOnSymbolInfo is used
+var SymbolInfoFields = []string{
+	FieldDescription, FieldType, FieldExchange, FieldCurrency, FieldSector, FieldIndustry, FieldCountry,
+	FieldPriceScale, FieldMinMove, FieldMinMove2, FieldFractional, FieldFrontContract,
+}
+
+// SymbolInfo - Metadata of a symbol, sent in its first update
+type SymbolInfo struct {
+	Description string `mapstructure:"description"`
+	Type        string `mapstructure:"type"`
+	Exchange    string `mapstructure:"exchange"`
+	Currency    string `mapstructure:"currency_code"`
+	// Classification of the equities, empty for the rest of the symbols
+	Sector   string `mapstructure:"sector"`
+	Industry string `mapstructure:"industry"`
+	Country  string `mapstructure:"country_code"`
+	// The minimum price change is MinMove / PriceScale
+	PriceScale int `mapstructure:"pricescale"`
+	MinMove    int `mapstructure:"minmov"`
+	// Fractional prices (bonds, some futures) are shown as whole'numerator, in 1/PriceScale units,
+	// or in 1/(PriceScale/MinMove2) units when MinMove2 is set
+	MinMove2   int  `mapstructure:"minmove2"`
+	Fractional bool `mapstructure:"fractional"`
+	// FrontContract is the contract followed by the continuous futures (ESH2021 for ES1!...), empty for the rest
+	FrontContract string `mapstructure:"front_contract"`
+	// Timezone and Session are the time zone of the exchange (America/New_York) and its trading hours (0930-1600),
+	// and HasSeconds tells if there are candles of seconds. They're only set in the info of the resolved symbol
+	// of the chart sessions, see WithOnSymbolResolved
+	Timezone   string `mapstructure:"timezone"`
+	Session    string `mapstructure:"session"`
+	HasSeconds bool   `mapstructure:"has_seconds"`
+}
+
+// GetSymbolInfo returns the last metadata received for the symbol. It's only requested when WithOnSymbolInfo is used,
+// unless the fields are requested with WithFields.
+func (s *Socket) GetSymbolInfo(symbol string) (info *SymbolInfo, ok bool) {
+	s.symbolInfosMutex.RLock()
+	defer s.symbolInfosMutex.RUnlock()
+
+	stored, ok := s.symbolInfos[symbol]
+	if !ok {
+		return
+	}
+
+	copied := *stored
+	info = &copied
+	return
+}
+
+// handleSymbolInfo decodes the metadata of the symbol when the values include it, merging it with the one
+// received before, and delivers it to the callback
+func (s *Socket) handleSymbolInfo(symbol string, values map[string]interface{}) (err error) {
+	if !hasAnyField(values, SymbolInfoFields) {
+		return
+	}
+
+	s.symbolInfosMutex.Lock()
+	info := &SymbolInfo{}
+	if stored, ok := s.symbolInfos[symbol]; ok {
+		*info = *stored
+	}
+	err = mapstructure.Decode(values, info)
+	if err != nil {
+		s.symbolInfosMutex.Unlock()
+		return
+	}
+	if s.symbolInfos == nil {
+		s.symbolInfos = map[string]*SymbolInfo{}
+	}
+	s.symbolInfos[symbol] = info
+	s.symbolInfosMutex.Unlock()
+
+	if s.onSymbolInfo != nil {
+		copied := *info
+		s.onSymbolInfo(symbol, &copied)
+	}
+	return
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,118 @@
+package tradingview
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// Timeframe - Resolution of the candles of a chart session, as TradingView names them
+type Timeframe string
+
+// Timeframe1Second ...
+const Timeframe1Second Timeframe = "1S"
+
+// Timeframe5Seconds ...
+const Timeframe5Seconds Timeframe = "5S"
+
+// Timeframe15Seconds ...
+const Timeframe15Seconds Timeframe = "15S"
+
+// Timeframe30Seconds ...
+const Timeframe30Seconds Timeframe = "30S"
+
+// Timeframe1Minute ...
+const Timeframe1Minute Timeframe = "1"
+
+// Timeframe3Minutes ...
+const Timeframe3Minutes Timeframe = "3"
+
+// Timeframe5Minutes ...
+const Timeframe5Minutes Timeframe = "5"
+
+// Timeframe15Minutes ...
+const Timeframe15Minutes Timeframe = "15"
+
+// Timeframe30Minutes ...
+const Timeframe30Minutes Timeframe = "30"
+
+// Timeframe45Minutes ...
+const Timeframe45Minutes Timeframe = "45"
+
+// Timeframe1Hour ...
+const Timeframe1Hour Timeframe = "60"
+
+// Timeframe2Hours ...
+const Timeframe2Hours Timeframe = "120"
+
+// Timeframe3Hours ...
+const Timeframe3Hours Timeframe = "180"
+
+// Timeframe4Hours ...
+const Timeframe4Hours Timeframe = "240"
+
+// Timeframe1Day ...
+const Timeframe1Day Timeframe = "D"
+
+// Timeframe1Week ...
+const Timeframe1Week Timeframe = "W"
+
+// Timeframe1Month ...
+const Timeframe1Month Timeframe = "M"
+
+// timeframeRegexp matches a number of seconds (15S), minutes (5), days (2D), weeks (W) or months (3M)
+var timeframeRegexp = regexp.MustCompile(`^([1-9][0-9]*)?([SDWM]?)$`)
+
+// ParseTimeframe validates the timeframe, returning an error for the ones TradingView doesn't support
+func ParseTimeframe(timeframe string) (Timeframe, error) {
+	t := Timeframe(timeframe)
+	return t, t.Validate()
+}
+
+// Validate ...
+func (t Timeframe) Validate() error {
+	matches := timeframeRegexp.FindStringSubmatch(string(t))
+	if t == "" || matches == nil || (matches[1] == "" && matches[2] == "S") {
+		return errors.New("invalid timeframe " + string(t))
+	}
+
+	// Bigger intraday resolutions must be given in days
+	if matches[2] == "" {
+		if minutes, _ := strconv.Atoi(matches[1]); minutes > 1440 {
+			return errors.New("invalid timeframe " + string(t) + ", the intraday timeframes can't be longer than a day")
+		}
+	}
+	return nil
+}
+
+// Duration returns the length of the candles of the timeframe. A month is 30 days.
+func (t Timeframe) Duration() time.Duration {
+	matches := timeframeRegexp.FindStringSubmatch(string(t))
+	if matches == nil {
+		return 0
+	}
+
+	count := 1
+	if matches[1] != "" {
+		count, _ = strconv.Atoi(matches[1])
+	}
+
+	unit := time.Minute
+	switch matches[2] {
+	case "S":
+		unit = time.Second
+	case "D":
+		unit = 24 * time.Hour
+	case "W":
+		unit = 7 * 24 * time.Hour
+	case "M":
+		unit = 30 * 24 * time.Hour
+	}
+	return time.Duration(count) * unit
+}
+
+// IsIntraday tells if the candles of the timeframe are shorter than a day
+func (t Timeframe) IsIntraday() bool {
+	return t.Duration() < 24*time.Hour
+}
@@ -0,0 +1,39 @@
+package tradingview
+
+import (
+	"errors"
+	"time"
+)
+
+// ExchangeTimezone makes TradingView use the timezone of the exchange of every symbol
+const ExchangeTimezone = "exchange"
+
+// Timezone returns the timezone set with WithTimezone, Etc/UTC by default
+func (s *Socket) Timezone() string {
+	if s.timezone == "" {
+		return "Etc/UTC"
+	}
+	return s.timezone
+}
+
+// sendTimezone sends switch_timezone for the given session, so its session and bar boundaries
+// are aligned with the timezone of the user. Nothing is sent if no timezone has been set.
+func (s *Socket) sendTimezone(sessionID string) error {
+	if s.timezone == "" {
+		return nil
+	}
+	return s.sendSocketMessage(getSocketMessage("switch_timezone", []string{sessionID, s.timezone}))
+}
+
+func validateTimezone(timezone string) error {
+	if timezone == "" {
+		return errors.New("the timezone can't be empty")
+	}
+	if timezone == ExchangeTimezone {
+		return nil
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return errors.New("unknown timezone " + timezone)
+	}
+	return nil
+}
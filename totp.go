@@ -0,0 +1,45 @@
+package tradingview
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// totpPeriod and totpDigits are the RFC 6238 defaults, the ones used by TradingView
+const totpPeriod = 30
+const totpDigits = 6
+
+// GenerateTOTP returns the one-time code for the given base32 secret (the one shown when enabling 2FA) at the given time
+func GenerateTOTP(secret string, t time.Time) (code string, err error) {
+	secret = strings.ToUpper(strings.ReplaceAll(secret, " ", ""))
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(strings.TrimRight(secret, "="))
+	if err != nil {
+		return
+	}
+
+	return generateTOTP(key, t, totpDigits), nil
+}
+
+// generateTOTP computes the code of the key at the given time with the number of digits, as RFC 6238 defines it
+func generateTOTP(key []byte, t time.Time, digits int) string {
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(t.Unix()/totpPeriod))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	modulus := uint32(1)
+	for i := 0; i < digits; i++ {
+		modulus *= 10
+	}
+	return fmt.Sprintf("%0*d", digits, value%modulus)
+}
@@ -0,0 +1,37 @@
+package tradingview
+
+import (
+	"testing"
+	"time"
+)
+
+// The SHA-1 test vectors of RFC 6238, with the secret "12345678901234567890"
+var totpTestVectors = []struct {
+	time int64
+	code string
+}{
+	{59, "94287082"},
+	{1111111109, "07081804"},
+	{1111111111, "14050471"},
+	{1234567890, "89005924"},
+	{2000000000, "69279037"},
+	{20000000000, "65353130"},
+}
+
+func TestGenerateTOTP(t *testing.T) {
+	for _, vector := range totpTestVectors {
+		code := generateTOTP([]byte("12345678901234567890"), time.Unix(vector.time, 0), 8)
+		if code != vector.code {
+			t.Fatalf("expected the code %s at %d, got %s", vector.code, vector.time, code)
+		}
+
+		// The codes of 6 digits are the last ones of the codes of 8
+		code, err := GenerateTOTP("GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ", time.Unix(vector.time, 0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if code != vector.code[2:] {
+			t.Fatalf("expected the code %s at %d, got %s", vector.code[2:], vector.time, code)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package tradingview
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TradingHours - Sessions of a symbol, as declared by the exchange in its local time
+type TradingHours struct {
+	Location *time.Location
+	segments []tradingHoursSegment
+}
+
+// SessionBounds - Open and close of a session
+type SessionBounds struct {
+	Open  time.Time
+	Close time.Time
+}
+
+// tradingHoursSegment is a session in minutes of the day. When open isn't before close it's an overnight session,
+// which opens the day before the trading day.
+type tradingHoursSegment struct {
+	open  int
+	close int
+	// days are the trading days, 1 for Sunday to 7 for Saturday as TradingView numbers them
+	days [8]bool
+}
+
+// ParseTradingHours parses the session of a symbol as TradingView declares it, "0930-1600", "0930-1600:23456",
+// "1700-1600:23456" for the overnight ones, "0400-0930,0930-1600" for many per day,
+// "0930-1600:23456|1000-1400:1" for different ones per day, or "24x7"
+func ParseTradingHours(spec string, location *time.Location) (hours *TradingHours, err error) {
+	if location == nil {
+		location = time.UTC
+	}
+	hours = &TradingHours{Location: location}
+
+	spec = strings.TrimSpace(spec)
+	if spec == "24x7" {
+		segment := tradingHoursSegment{open: 0, close: 24 * 60}
+		for day := 1; day <= 7; day++ {
+			segment.days[day] = true
+		}
+		hours.segments = append(hours.segments, segment)
+		return
+	}
+
+	for _, group := range strings.Split(spec, "|") {
+		ranges, days := group, "1234567"
+		if i := strings.Index(group, ":"); i >= 0 {
+			ranges, days = group[:i], group[i+1:]
+		}
+
+		for _, r := range strings.Split(ranges, ",") {
+			var segment tradingHoursSegment
+			segment, err = parseTradingHoursSegment(r, days)
+			if err != nil {
+				return nil, err
+			}
+			hours.segments = append(hours.segments, segment)
+		}
+	}
+	return
+}
+
+func parseTradingHoursSegment(r string, days string) (segment tradingHoursSegment, err error) {
+	parts := strings.Split(strings.TrimSpace(r), "-")
+	if len(parts) != 2 {
+		return segment, errors.New("invalid session " + r)
+	}
+
+	segment.open, err = parseSessionTime(parts[0])
+	if err != nil {
+		return
+	}
+	segment.close, err = parseSessionTime(parts[1])
+	if err != nil {
+		return
+	}
+	// 0000 as the close is the end of the day
+	if segment.close == 0 {
+		segment.close = 24 * 60
+	}
+
+	for _, day := range days {
+		if day < '1' || day > '7' {
+			return segment, errors.New("invalid session days " + days)
+		}
+		segment.days[day-'0'] = true
+	}
+	return
+}
+
+// parseSessionTime parses "0930" as the minutes of the day
+func parseSessionTime(hhmm string) (minutes int, err error) {
+	if len(hhmm) != 4 {
+		return 0, errors.New("invalid session time " + hhmm)
+	}
+	hours, err := strconv.Atoi(hhmm[:2])
+	if err != nil {
+		return
+	}
+	minutes, err = strconv.Atoi(hhmm[2:])
+	if err != nil {
+		return
+	}
+	if hours > 24 || minutes > 59 {
+		return 0, errors.New("invalid session time " + hhmm)
+	}
+	return hours*60 + minutes, nil
+}
+
+// Sessions returns the sessions of the trading day of the date, in the time zone of the exchange, earliest first.
+// The overnight sessions open the day before.
+func (h *TradingHours) Sessions(day time.Time) (sessions []SessionBounds) {
+	day = day.In(h.Location)
+	weekday := int(day.Weekday()) + 1
+	// The times are built from the date, so they're right on the days the clocks change
+	at := func(minutes int) time.Time {
+		return time.Date(day.Year(), day.Month(), day.Day(), minutes/60, minutes%60, 0, 0, h.Location)
+	}
+
+	for _, segment := range h.segments {
+		if !segment.days[weekday] {
+			continue
+		}
+
+		open := at(segment.open)
+		if segment.open >= segment.close {
+			open = open.AddDate(0, 0, -1)
+		}
+		sessions = append(sessions, SessionBounds{Open: open, Close: at(segment.close)})
+	}
+
+	for i := 1; i < len(sessions); i++ {
+		for j := i; j > 0 && sessions[j].Open.Before(sessions[j-1].Open); j-- {
+			sessions[j], sessions[j-1] = sessions[j-1], sessions[j]
+		}
+	}
+	return
+}
+
+// IsOpen tells if the time is inside a session. The holidays are not taken into account.
+func (h *TradingHours) IsOpen(t time.Time) bool {
+	// An overnight session of the next trading day may have opened already
+	for _, day := range []time.Time{t, t.AddDate(0, 0, 1)} {
+		for _, session := range h.Sessions(day) {
+			if !t.Before(session.Open) && t.Before(session.Close) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+package tradingview
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTradingHours(t *testing.T) {
+	// 2024-03-11 is a Monday
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, 3, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		spec     string
+		day      time.Time
+		expected []SessionBounds
+		invalid  bool
+	}{
+		{
+			name:     "every day",
+			spec:     "0930-1600",
+			day:      at(10, 0, 0),
+			expected: []SessionBounds{{Open: at(10, 9, 30), Close: at(10, 16, 0)}},
+		},
+		{
+			name:     "weekdays",
+			spec:     "0930-1600:23456",
+			day:      at(11, 0, 0),
+			expected: []SessionBounds{{Open: at(11, 9, 30), Close: at(11, 16, 0)}},
+		},
+		{
+			name: "weekend",
+			spec: "0930-1600:23456",
+			day:  at(10, 0, 0),
+		},
+		{
+			name:     "overnight",
+			spec:     "1700-1600:23456",
+			day:      at(11, 0, 0),
+			expected: []SessionBounds{{Open: at(10, 17, 0), Close: at(11, 16, 0)}},
+		},
+		{
+			name: "many per day",
+			spec: "0930-1600,0400-0930",
+			day:  at(11, 0, 0),
+			expected: []SessionBounds{
+				{Open: at(11, 4, 0), Close: at(11, 9, 30)},
+				{Open: at(11, 9, 30), Close: at(11, 16, 0)},
+			},
+		},
+		{
+			name:     "different per day",
+			spec:     "0930-1600:23456|1000-1400:1",
+			day:      at(10, 0, 0),
+			expected: []SessionBounds{{Open: at(10, 10, 0), Close: at(10, 14, 0)}},
+		},
+		{
+			name:     "until midnight",
+			spec:     "0800-0000",
+			day:      at(11, 0, 0),
+			expected: []SessionBounds{{Open: at(11, 8, 0), Close: at(12, 0, 0)}},
+		},
+		{
+			name:     "24x7",
+			spec:     "24x7",
+			day:      at(10, 0, 0),
+			expected: []SessionBounds{{Open: at(10, 0, 0), Close: at(11, 0, 0)}},
+		},
+		{
+			name:    "missing close",
+			spec:    "0930",
+			invalid: true,
+		},
+		{
+			name:    "invalid time",
+			spec:    "0970-1600",
+			invalid: true,
+		},
+		{
+			name:    "invalid days",
+			spec:    "0930-1600:089",
+			invalid: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			hours, err := ParseTradingHours(test.spec, time.UTC)
+			if test.invalid {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sessions := hours.Sessions(test.day)
+			if len(sessions) != len(test.expected) {
+				t.Fatalf("expected %d sessions, got %d", len(test.expected), len(sessions))
+			}
+			for i, session := range sessions {
+				expected := test.expected[i]
+				if !session.Open.Equal(expected.Open) || !session.Close.Equal(expected.Close) {
+					t.Fatalf("expected the session %v - %v, got %v - %v", expected.Open, expected.Close, session.Open, session.Close)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package tradingview
+
+// TradingSession - Trading session of a symbol, from the current_session quote field
+type TradingSession string
+
+// TradingSessionPreMarket ...
+const TradingSessionPreMarket TradingSession = "pre_market"
+
+// TradingSessionMarket ...
+const TradingSessionMarket TradingSession = "market"
+
+// TradingSessionPostMarket ...
+const TradingSessionPostMarket TradingSession = "post_market"
+
+// TradingSessionOutOfSession ...
+const TradingSessionOutOfSession TradingSession = "out_of_session"
+
+// ExtendedSessionFields are the quote fields needed to follow the pre-market and after-hours trading
+var ExtendedSessionFields = []string{
+	FieldExtendedPrice, FieldExtendedPriceTime, FieldExtendedChange, FieldExtendedChangePercent,
+	FieldPremarketVolume, FieldPostmarketVolume, FieldCurrentSession,
+}
+
+// setTradingSession remembers the current session of the symbol when it's received, and marks the update with it.
+// It returns the previous session of the symbol and whether it has changed.
+func (qs *QuoteSession) setTradingSession(symbol string, data *QuoteData) (previous TradingSession, changed bool) {
+	qs.mutex.Lock()
+	defer qs.mutex.Unlock()
+
+	previous = qs.tradingSessions[symbol]
+	if data.CurrentSession != nil {
+		qs.tradingSessions[symbol] = TradingSession(*data.CurrentSession)
+	}
+	data.TradingSession = qs.tradingSessions[symbol]
+
+	changed = data.TradingSession != previous
+	return
+}
@@ -0,0 +1,117 @@
+package tradingview
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Transport is the connection the socket reads and writes the frames through.
+// The message types are the websocket ones, only websocket.TextMessage frames are parsed.
+// ReadFrame should return a *CloseError when the server closes the connection.
+type Transport interface {
+	ReadFrame() (msgType int, data []byte, err error)
+	WriteFrame(msgType int, data []byte) error
+	Close() error
+}
+
+// DeadlineTransport can be implemented by a Transport to support WithReadTimeout and WithWriteTimeout
+type DeadlineTransport interface {
+	SetReadDeadline(t time.Time) error
+	SetWriteDeadline(t time.Time) error
+}
+
+// PingTransport can be implemented by a Transport to support WithPing and the latency measurement.
+// The pong handler must be called with the payload of the ping.
+type PingTransport interface {
+	WritePing(data []byte, deadline time.Time) error
+	SetPongHandler(handler func(appData string) error)
+}
+
+// TransportFactory opens a new transport to the endpoint, sending the given handshake headers
+type TransportFactory func(ctx context.Context, endpoint string, headers http.Header) (Transport, error)
+
+// MessageTooLargeError is returned when a frame exceeds the limit set with WithReadLimit
+type MessageTooLargeError struct {
+	Limit int64
+}
+
+func (e *MessageTooLargeError) Error() string {
+	return "the message exceeds the read limit of " + strconv.FormatInt(e.Limit, 10) + " bytes"
+}
+
+// CloseError is returned when the server closes the connection, with the close code and reason it sent.
+// The codes are the standard websocket ones, see the websocket.Close* constants.
+type CloseError struct {
+	Code   int
+	Reason string
+}
+
+func (e *CloseError) Error() string {
+	return "the server closed the connection with code " + strconv.Itoa(e.Code) + " - " + e.Reason
+}
+
+type websocketTransport struct {
+	conn      *websocket.Conn
+	readLimit int64
+}
+
+// NewWebsocketTransport wraps a gorilla/websocket connection, it's the transport used by default
+func NewWebsocketTransport(conn *websocket.Conn) Transport {
+	return &websocketTransport{conn: conn}
+}
+
+func (t *websocketTransport) ReadFrame() (int, []byte, error) {
+	msgType, data, err := t.conn.ReadMessage()
+	if err == websocket.ErrReadLimit {
+		err = &MessageTooLargeError{Limit: t.readLimit}
+	}
+	if closeErr, isCloseErr := err.(*websocket.CloseError); isCloseErr {
+		err = &CloseError{Code: closeErr.Code, Reason: closeErr.Text}
+	}
+
+	return msgType, data, err
+}
+
+func (t *websocketTransport) WriteFrame(msgType int, data []byte) error {
+	return t.conn.WriteMessage(msgType, data)
+}
+
+func (t *websocketTransport) Close() error {
+	return t.conn.Close()
+}
+
+func (t *websocketTransport) SetReadDeadline(deadline time.Time) error {
+	return t.conn.SetReadDeadline(deadline)
+}
+
+func (t *websocketTransport) SetWriteDeadline(deadline time.Time) error {
+	return t.conn.SetWriteDeadline(deadline)
+}
+
+func (t *websocketTransport) WritePing(data []byte, deadline time.Time) error {
+	return t.conn.WriteControl(websocket.PingMessage, data, deadline)
+}
+
+func (t *websocketTransport) SetPongHandler(handler func(appData string) error) {
+	t.conn.SetPongHandler(handler)
+}
+
+// dialWebsocket is the default TransportFactory, it uses the dialer configured through the options
+func (s *Socket) dialWebsocket(ctx context.Context, endpoint string, headers http.Header) (Transport, error) {
+	conn, _, err := s.getDialer().DialContext(ctx, endpoint, headers)
+	if err != nil {
+		return nil, err
+	}
+	if s.compression {
+		conn.EnableWriteCompression(true)
+	}
+	if s.readLimit > 0 {
+		conn.SetReadLimit(s.readLimit)
+	}
+
+	return &websocketTransport{conn: conn, readLimit: s.readLimit}, nil
+}
@@ -1,11 +1,45 @@
 package tradingview
 
+import (
+	"context"
+	"time"
+)
+
 // SocketInterface ...
 type SocketInterface interface {
 	AddSymbol(symbol string) error
 	RemoveSymbol(symbol string) error
+	AddSymbols(symbols []string) error
+	AddSymbolAndWait(ctx context.Context, symbol string) error
+	Subscribe(symbol string, callback OnReceiveDataCallback) error
+	Unsubscribe(symbol string) error
+	RemoveSymbols(symbols []string) error
+	SubscribeWatchlist(name string) error
+	UnsubscribeWatchlist(name string) error
+	ListSymbols() []string
+	IsSubscribed(symbol string) bool
+	SetFields(fields ...string) error
+	GetLatest(symbol string) (*QuoteData, bool)
+	Pause() error
+	Resume() error
+	NewQuoteSession(onReceiveData OnReceiveDataCallback, fields ...string) (*QuoteSession, error)
+	NewChartSession(symbol string, timeframe Timeframe, barCount int, onCandle OnCandleCallback, options ...ChartOption) (*ChartSession, error)
+	StreamBars(symbol string, timeframe Timeframe, barCount int, onHistory OnHistoryCallback, onCandle OnCandleCallback, options ...ChartOption) (*ChartSession, error)
+	NewReplaySession(symbol string, timeframe Timeframe, from time.Time, barCount int, onCandle OnCandleCallback) (*ReplaySession, error)
+	GetHistory(ctx context.Context, symbol string, timeframe Timeframe, barCount int, options ...ChartOption) ([]*Candle, error)
+	GetEconomicSeries(ctx context.Context, indicator string, timeframe Timeframe, count int) ([]*EconomicValue, error)
+	GetHistoryRange(ctx context.Context, symbol string, timeframe Timeframe, from time.Time, to time.Time, options ...ChartOption) ([]*Candle, error)
+	SetAuthToken(token string) error
 	Init() error
 	Close() error
+	Shutdown(ctx context.Context) error
+	IsConnected() bool
+	LastMessageAt() time.Time
+	ReconnectCount() int64
+	Latency() time.Duration
+	Locale() (language string, country string)
+	Timezone() string
+	GetSessionID() string
 }
 
 // SocketMessage ...
@@ -16,17 +50,78 @@ type SocketMessage struct {
 
 // QuoteMessage ...
 type QuoteMessage struct {
-	Symbol string     `mapstructure:"n"`
-	Status string     `mapstructure:"s"`
-	Data   *QuoteData `mapstructure:"v"`
+	Symbol string `mapstructure:"n"`
+	Status string `mapstructure:"s"`
+	// ErrorMessage is only sent when the status is error
+	ErrorMessage string     `mapstructure:"errmsg"`
+	Data         *QuoteData `mapstructure:"v"`
 }
 
 // QuoteData ...
 type QuoteData struct {
 	Price  *float64 `mapstructure:"lp"`
 	Volume *float64 `mapstructure:"volume"`
-	Bid    *float64 `mapstructure:"bid"`
-	Ask    *float64 `mapstructure:"ask"`
+	// VolumeDelta is the volume traded since the previous update, only set when WithVolumeDelta is used
+	VolumeDelta *float64 `mapstructure:"-"`
+	Bid         *float64 `mapstructure:"bid"`
+	Ask         *float64 `mapstructure:"ask"`
+
+	BidSize *float64 `mapstructure:"bid_size"`
+	AskSize *float64 `mapstructure:"ask_size"`
+	// Spread is only set when WithSpread is used and the update includes the bid or the ask
+	Spread *Spread `mapstructure:"-"`
+
+	// Daily stats, only received when their fields are requested
+	Change        *float64 `mapstructure:"ch"`
+	ChangePercent *float64 `mapstructure:"chp"`
+	Open          *float64 `mapstructure:"open_price"`
+	High          *float64 `mapstructure:"high_price"`
+	Low           *float64 `mapstructure:"low_price"`
+	PrevClose     *float64 `mapstructure:"prev_close_price"`
+	AverageVolume *float64 `mapstructure:"average_volume"`
+
+	// Extended session (pre-market and after-hours), only received when their fields are requested
+	ExtendedPrice     *float64 `mapstructure:"rtc"`
+	ExtendedPriceTime *int64   `mapstructure:"rtc_time"`
+	// ExtendedChange and ExtendedChangePercent are relative to the close of the regular session,
+	// so in the pre-market they're the gap of the symbol
+	ExtendedChange        *float64 `mapstructure:"rch"`
+	ExtendedChangePercent *float64 `mapstructure:"rchp"`
+	PremarketVolume       *float64 `mapstructure:"premarket_volume"`
+	PostmarketVolume      *float64 `mapstructure:"postmarket_volume"`
+	CurrentSession        *string  `mapstructure:"current_session"`
+	// TradingSession is the session the update belongs to. Unlike CurrentSession, which is only sent when it changes,
+	// it's set in every update once the current_session field has been received for the symbol.
+	TradingSession TradingSession `mapstructure:"-"`
+
+	Currency *string `mapstructure:"currency_code"`
+	// The prices converted into the currency given with WithCurrencyConversion,
+	// nil until the exchange rate of the currency of the symbol has been received
+	ConvertedCurrency string   `mapstructure:"-"`
+	ConvertedPrice    *float64 `mapstructure:"-"`
+	ConvertedBid      *float64 `mapstructure:"-"`
+	ConvertedAsk      *float64 `mapstructure:"-"`
+
+	// Fundamentals is nil when the update doesn't include any of the FundamentalFields, ShareFields or CorporateEventFields
+	Fundamentals *Fundamentals `mapstructure:"-"`
+
+	// Only received when the HaltFields are requested
+	IsTradable *bool `mapstructure:"is_tradable"`
+	IsHalted   *bool `mapstructure:"is_halted"`
+
+	UpdateMode *string `mapstructure:"update_mode"`
+	// Delay is the delay of the data in seconds, 0 when it's realtime. Only received when the delay field is requested.
+	Delay *float64 `mapstructure:"delay"`
+
+	// LastPriceTime is the unix time in seconds of the last trade, sent by the server with the lp_time field
+	LastPriceTime *int64 `mapstructure:"lp_time"`
+	// ReceivedAt is the local time when the message was read from the connection.
+	// It keeps the monotonic clock reading, so time.Since(data.ReceivedAt) is safe to measure staleness.
+	ReceivedAt time.Time `mapstructure:"-"`
+
+	// UpdateType tells if this is the first update of the symbol or a delta with only the ChangedFields
+	UpdateType    UpdateType `mapstructure:"-"`
+	ChangedFields []string   `mapstructure:"-"`
 }
 
 // Flags ...
@@ -39,3 +134,57 @@ type OnReceiveDataCallback func(symbol string, data *QuoteData)
 
 // OnErrorCallback ...
 type OnErrorCallback func(err error, context string)
+
+// Gap - Window of time in which the data of the symbols may have been missed due to a reconnection
+type Gap struct {
+	// From is when the last data was received before the connection was lost
+	From time.Time
+	// To is when the connection was restored and the symbols were subscribed again
+	To      time.Time
+	Symbols []string
+}
+
+// OnGapDetectedCallback ...
+type OnGapDetectedCallback func(gap *Gap)
+
+// OnCloseCallback ...
+type OnCloseCallback func(code int, reason string)
+
+// OnEconomicEventCallback ...
+type OnEconomicEventCallback func(event *EconomicEvent)
+
+// OnNewsHeadlineCallback ...
+type OnNewsHeadlineCallback func(headline *NewsHeadline)
+
+// OnSymbolInfoCallback ...
+type OnSymbolInfoCallback func(symbol string, info *SymbolInfo)
+
+// OnMarketStatusChangedCallback ...
+type OnMarketStatusChangedCallback func(event *MarketStatusChanged)
+
+// OnTradingHaltCallback ...
+type OnTradingHaltCallback func(event *TradingHaltChanged)
+
+// OnCandleCallback ...
+type OnCandleCallback func(symbol string, candle *Candle)
+
+// OnStudyValueCallback ...
+type OnStudyValueCallback func(symbol string, study string, value *StudyValue)
+
+// OnStrategyReportCallback ...
+type OnStrategyReportCallback func(symbol string, report *StrategyReport)
+
+// OnVolumeProfileCallback ...
+type OnVolumeProfileCallback func(symbol string, profile *VolumeProfile)
+
+// OnHistoryProgressCallback ...
+type OnHistoryProgressCallback func(symbol string, loaded int, total int)
+
+// OnHistoryGapsCallback ...
+type OnHistoryGapsCallback func(symbol string, gaps []*BarGap)
+
+// OnHistoryCallback ...
+type OnHistoryCallback func(symbol string, candles []*Candle)
+
+// OnStaleConnectionCallback ...
+type OnStaleConnectionCallback func(lastMessageAt time.Time)
@@ -3,6 +3,8 @@ package tradingview
 import (
 	"encoding/json"
 	"math/rand"
+	"os"
+	"path/filepath"
 	"time"
 )
 
@@ -35,3 +37,22 @@ func GetStringRepresentation(data interface{}) string {
 	str, _ := json.Marshal(data)
 	return string(str)
 }
+
+// writeFileAtomic writes the content to a temporary file and then renames it, so the file is never left half written
+func writeFileAtomic(path string, content []byte) (err error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(content)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,25 @@
+package tradingview
+
+// setVolumeDelta fills the volume traded since the previous update of the symbol.
+// The volume field is the cumulative volume of the day, so when it goes down a new session has started
+// and the delta is the whole new volume.
+func (qs *QuoteSession) setVolumeDelta(symbol string, data *QuoteData) {
+	if data.Volume == nil {
+		return
+	}
+
+	qs.mutex.Lock()
+	defer qs.mutex.Unlock()
+
+	previous, ok := qs.lastVolumes[symbol]
+	qs.lastVolumes[symbol] = *data.Volume
+	if !ok {
+		return
+	}
+
+	delta := *data.Volume - previous
+	if delta < 0 {
+		delta = *data.Volume
+	}
+	data.VolumeDelta = &delta
+}
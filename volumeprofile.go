@@ -0,0 +1,142 @@
+package tradingview
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+)
+
+// StudyVolumeProfile is the Volume Profile of a fixed range, the volume traded at every price level
+const StudyVolumeProfile = "VbPFixed@tv-basicstudies-241"
+
+// VolumeProfile - Volume traded at every price level of a range of time, lowest price first
+type VolumeProfile struct {
+	Rows []*VolumeProfileRow
+}
+
+// VolumeProfileRow - Volume traded between two prices, split in the volume of the up and the down bars
+type VolumeProfileRow struct {
+	PriceLow   float64
+	PriceHigh  float64
+	UpVolume   float64
+	DownVolume float64
+	From       time.Time
+	To         time.Time
+}
+
+// Volume ...
+func (r *VolumeProfileRow) Volume() float64 {
+	return r.UpVolume + r.DownVolume
+}
+
+// PointOfControl returns the row with the highest volume, nil if the profile is empty
+func (p *VolumeProfile) PointOfControl() (poc *VolumeProfileRow) {
+	for _, row := range p.Rows {
+		if poc == nil || row.Volume() > poc.Volume() {
+			poc = row
+		}
+	}
+	return
+}
+
+// volumeProfileGraphics are the drawing commands of the study, the rows of the profile are horizontal histograms
+type volumeProfileGraphics struct {
+	GraphicsCmds struct {
+		Erase []struct {
+			Action string `json:"action"`
+		} `json:"erase"`
+		Create struct {
+			HHists []struct {
+				Data []struct {
+					ID           int64     `json:"id"`
+					PriceLow     float64   `json:"priceLow"`
+					PriceHigh    float64   `json:"priceHigh"`
+					FirstBarTime float64   `json:"firstBarTime"`
+					LastBarTime  float64   `json:"lastBarTime"`
+					Rate         []float64 `json:"rate"`
+				} `json:"data"`
+			} `json:"hhists"`
+		} `json:"create"`
+	} `json:"graphicsCmds"`
+}
+
+// AddVolumeProfile adds the Volume Profile of the bars between from and to, with the given number of price levels.
+// onProfile receives the whole profile every time the server sends it.
+func (cs *ChartSession) AddVolumeProfile(from time.Time, to time.Time, rows int, onProfile OnVolumeProfileCallback) (study *Study, err error) {
+	if !from.Before(to) {
+		return nil, errors.New("the start of the range must be before its end")
+	}
+	if rows <= 0 {
+		return nil, errors.New("the number of rows of the profile must be positive")
+	}
+	if onProfile == nil {
+		return nil, errors.New("the profile callback can't be nil")
+	}
+
+	inputs := map[string]interface{}{
+		"rowsLayout":                     "Number Of Rows",
+		"rows":                           rows,
+		"volume":                         "Up/Down",
+		"vaVolume":                       70,
+		"subscribeRealtime":              false,
+		"first_bar_time":                 from.UnixNano() / int64(time.Millisecond),
+		"last_bar_time":                  to.UnixNano() / int64(time.Millisecond),
+		"extendToRight":                  false,
+		"mapRightBoundaryToBarStartTime": true,
+	}
+
+	return cs.addStudy(&Study{script: StudyVolumeProfile, inputs: inputs, onProfile: onProfile})
+}
+
+// handleVolumeProfileUpdate merges the rows in the update of the study and delivers the whole profile
+func (st *Study) handleVolumeProfileUpdate(fields map[string]interface{}) (err error) {
+	data, err := decodeStudyData(fields)
+	if err != nil || data == nil {
+		return
+	}
+
+	var graphics volumeProfileGraphics
+	err = json.Unmarshal(data, &graphics)
+	if err != nil {
+		return
+	}
+
+	cmds := graphics.GraphicsCmds
+	for _, erase := range cmds.Erase {
+		if erase.Action == "all" {
+			st.profileRows = nil
+		}
+	}
+	if len(cmds.Create.HHists) == 0 {
+		return
+	}
+
+	if st.profileRows == nil {
+		st.profileRows = map[int64]*VolumeProfileRow{}
+	}
+	for _, hhist := range cmds.Create.HHists {
+		for _, row := range hhist.Data {
+			rates := make([]float64, 2)
+			copy(rates, row.Rate)
+			st.profileRows[row.ID] = &VolumeProfileRow{
+				PriceLow:   row.PriceLow,
+				PriceHigh:  row.PriceHigh,
+				UpVolume:   rates[0],
+				DownVolume: rates[1],
+				From:       time.Unix(int64(row.FirstBarTime), 0),
+				To:         time.Unix(int64(row.LastBarTime), 0),
+			}
+		}
+	}
+
+	profile := &VolumeProfile{Rows: make([]*VolumeProfileRow, 0, len(st.profileRows))}
+	for _, row := range st.profileRows {
+		copied := *row
+		profile.Rows = append(profile.Rows, &copied)
+	}
+	sort.Slice(profile.Rows, func(i, j int) bool { return profile.Rows[i].PriceLow < profile.Rows[j].PriceLow })
+
+	st.onProfile(st.chart.symbol, profile)
+	return
+}
@@ -0,0 +1,44 @@
+package tradingview
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// watchdog checks periodically when the last message was received.
+// It reports every stale period only once, so the callback is not spammed while the feed is stuck.
+func (s *Socket) watchdog() {
+	ticker := time.NewTicker(s.staleTimeout / 2)
+	defer ticker.Stop()
+
+	var lastReported int64
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		// No data is expected while paused
+		if s.IsPaused() {
+			continue
+		}
+
+		lastMessageAt := atomic.LoadInt64(&s.lastMessageAt)
+		if lastMessageAt == lastReported || time.Since(time.Unix(0, lastMessageAt)) < s.staleTimeout {
+			continue
+		}
+		lastReported = lastMessageAt
+
+		if s.onStale != nil {
+			s.onStale(time.Unix(0, lastMessageAt))
+		}
+		if s.reconnectPolicy != nil {
+			s.onError(
+				errors.New("no data received since "+time.Unix(0, lastMessageAt).String()),
+				StaleConnectionErrorContext,
+			)
+		}
+	}
+}
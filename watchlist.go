@@ -0,0 +1,264 @@
+package tradingview
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// WatchlistsURL ...
+const WatchlistsURL = "https://www.tradingview.com/api/v1/symbols_list/custom/"
+
+// DefaultWatchlistSyncInterval is how often SubscribeWatchlist requests the watchlist again to follow its changes
+const DefaultWatchlistSyncInterval = time.Minute
+
+// watchlistSectionPrefix is the prefix of the section titles of the watchlists, listed with the symbols
+const watchlistSectionPrefix = "###"
+
+// Watchlist - Watchlist of a TradingView account
+type Watchlist struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	// Symbols have their exchange, as NASDAQ:AAPL
+	Symbols []string `json:"symbols"`
+	// Active is true for the watchlist open in the website
+	Active bool `json:"active"`
+}
+
+// watchlistSubscription - Watchlist followed by SubscribeWatchlist
+type watchlistSubscription struct {
+	// symbols are the symbols of the watchlist, true for the ones it added to the quote session,
+	// false for the ones that were already subscribed
+	symbols map[string]bool
+	cancel  context.CancelFunc
+}
+
+// GetWatchlists returns the watchlists of the account of the sessionid (and sessionid_sign) cookies,
+// see GetAuthTokenFromSession. The HTTP client can be nil to use http.DefaultClient.
+func GetWatchlists(
+	ctx context.Context,
+	client *http.Client,
+	sessionID string,
+	sessionIDSign string,
+) (watchlists []*Watchlist, err error) {
+	if sessionID == "" {
+		return nil, errors.New("the sessionid can't be empty")
+	}
+
+	req, err := newHTTPRequest(ctx, http.MethodGet, WatchlistsURL, nil)
+	if err != nil {
+		return
+	}
+	addSessionCookies(req, sessionID, sessionIDSign)
+
+	_, err = doHTTPRequest(client, req, &watchlists)
+	var statusErr *HTTPStatusError
+	if errors.As(err, &statusErr) && (statusErr.StatusCode == http.StatusUnauthorized || statusErr.StatusCode == http.StatusForbidden) {
+		return nil, ErrSessionNotAuthenticated
+	}
+	if err != nil {
+		return
+	}
+
+	for _, watchlist := range watchlists {
+		symbols := watchlist.Symbols[:0]
+		for _, symbol := range watchlist.Symbols {
+			if !strings.HasPrefix(symbol, watchlistSectionPrefix) {
+				symbols = append(symbols, symbol)
+			}
+		}
+		watchlist.Symbols = symbols
+	}
+	return
+}
+
+// SubscribeWatchlist adds all the symbols of the watchlist of the account to the default quote session,
+// and keeps requesting the watchlist to add the symbols added to it and remove the ones removed from it.
+// It needs the session cookies of the account, see WithSessionCookies.
+func (s *Socket) SubscribeWatchlist(name string) (err error) {
+	if s.sessionCookie == "" {
+		return errors.New("the watchlists need the session cookies of the account, see WithSessionCookies")
+	}
+
+	s.watchlistsMutex.Lock()
+	if _, ok := s.watchlists[name]; ok {
+		s.watchlistsMutex.Unlock()
+		return errors.New("already subscribed to the watchlist " + name)
+	}
+	ctx, cancel := context.WithCancel(s.ctx)
+	subscription := &watchlistSubscription{symbols: map[string]bool{}, cancel: cancel}
+	if s.watchlists == nil {
+		s.watchlists = map[string]*watchlistSubscription{}
+	}
+	s.watchlists[name] = subscription
+	s.watchlistsMutex.Unlock()
+
+	err = s.syncWatchlist(ctx, name, subscription)
+	if err != nil {
+		s.watchlistsMutex.Lock()
+		delete(s.watchlists, name)
+		s.watchlistsMutex.Unlock()
+		cancel()
+		return
+	}
+
+	go s.watchlistSyncLoop(ctx, name, subscription)
+	return
+}
+
+// UnsubscribeWatchlist stops following the watchlist, and removes the symbols it added that are not in other watchlists
+func (s *Socket) UnsubscribeWatchlist(name string) (err error) {
+	s.watchlistsMutex.Lock()
+	subscription, ok := s.watchlists[name]
+	if !ok {
+		s.watchlistsMutex.Unlock()
+		return errors.New("not subscribed to the watchlist " + name)
+	}
+	subscription.cancel()
+	delete(s.watchlists, name)
+
+	var removed []string
+	for symbol, added := range subscription.symbols {
+		if added && !s.isInWatchlists(symbol) {
+			removed = append(removed, symbol)
+		}
+	}
+	s.watchlistsMutex.Unlock()
+
+	if len(removed) == 0 {
+		return
+	}
+	return s.RemoveSymbols(removed)
+}
+
+func (s *Socket) watchlistSyncLoop(ctx context.Context, name string, subscription *watchlistSubscription) {
+	interval := s.watchlistSyncInterval
+	if interval == 0 {
+		interval = DefaultWatchlistSyncInterval
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+
+		err := s.syncWatchlist(ctx, name, subscription)
+		if err != nil && ctx.Err() == nil {
+			s.OnErrorCallback(err, WatchlistErrorContext)
+		}
+	}
+}
+
+// syncWatchlist requests the watchlist, and adds and removes the symbols that changed since the last time
+func (s *Socket) syncWatchlist(ctx context.Context, name string, subscription *watchlistSubscription) (err error) {
+	watchlists, err := GetWatchlists(ctx, s.getHTTPClient(), s.sessionCookie, s.sessionCookieSign)
+	if err != nil {
+		return
+	}
+
+	var watchlist *Watchlist
+	for _, w := range watchlists {
+		if w.Name == name {
+			watchlist = w
+			break
+		}
+	}
+	if watchlist == nil {
+		return errors.New("the account doesn't have the watchlist " + name)
+	}
+
+	listed := map[string]bool{}
+	var added []string
+	var removed []string
+
+	s.watchlistsMutex.Lock()
+	// It was unsubscribed while the watchlist was requested
+	if ctx.Err() != nil {
+		s.watchlistsMutex.Unlock()
+		return
+	}
+	for _, symbol := range watchlist.Symbols {
+		listed[symbol] = true
+		if _, ok := subscription.symbols[symbol]; ok {
+			continue
+		}
+
+		// The symbols added by other watchlists are shared, the last one to drop them removes them
+		if s.isAddedByWatchlists(symbol) {
+			subscription.symbols[symbol] = true
+			continue
+		}
+		// The symbols to add are only recorded once they've been added, so they're retried if it fails
+		if s.IsSubscribed(symbol) {
+			subscription.symbols[symbol] = false
+		} else {
+			added = append(added, symbol)
+		}
+	}
+	for symbol, wasAdded := range subscription.symbols {
+		if listed[symbol] {
+			continue
+		}
+
+		delete(subscription.symbols, symbol)
+		if wasAdded && !s.isInWatchlists(symbol) {
+			removed = append(removed, symbol)
+		}
+	}
+	s.watchlistsMutex.Unlock()
+
+	if len(added) > 0 {
+		err = s.AddSymbols(added)
+		if err != nil {
+			return
+		}
+		removed = append(removed, s.recordWatchlistSymbols(ctx, subscription, added)...)
+	}
+	if len(removed) > 0 {
+		err = s.RemoveSymbols(removed)
+	}
+	return
+}
+
+// recordWatchlistSymbols marks the symbols as added by the watchlist. If it was unsubscribed while they were added,
+// they're returned to be removed, unless other watchlists have them.
+func (s *Socket) recordWatchlistSymbols(ctx context.Context, subscription *watchlistSubscription, added []string) (removed []string) {
+	s.watchlistsMutex.Lock()
+	defer s.watchlistsMutex.Unlock()
+
+	for _, symbol := range added {
+		if ctx.Err() != nil {
+			if !s.isInWatchlists(symbol) {
+				removed = append(removed, symbol)
+			}
+			continue
+		}
+		subscription.symbols[symbol] = true
+	}
+	return
+}
+
+// isInWatchlists tells if any of the watchlists subscribed has the symbol, it must be called with the watchlists mutex locked
+func (s *Socket) isInWatchlists(symbol string) bool {
+	for _, subscription := range s.watchlists {
+		if _, ok := subscription.symbols[symbol]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// isAddedByWatchlists tells if any of the watchlists subscribed added the symbol to the quote session,
+// it must be called with the watchlists mutex locked
+func (s *Socket) isAddedByWatchlists(symbol string) bool {
+	for _, subscription := range s.watchlists {
+		if subscription.symbols[symbol] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,141 @@
+package tradingview
+
+import (
+	"errors"
+	"time"
+)
+
+// QueuePolicy decides what happens when a message is sent while the outgoing queue is full
+type QueuePolicy string
+
+// QueueBlock - Waits until there's room in the queue
+const QueueBlock QueuePolicy = "block"
+
+// QueueDropOldest - Discards the oldest queued message to make room for the new one
+const QueueDropOldest QueuePolicy = "drop-oldest"
+
+// QueueError - Fails straight away with ErrWriteQueueFull
+const QueueError QueuePolicy = "error"
+
+// DefaultWriteQueueSize ...
+const DefaultWriteQueueSize = 64
+
+// ErrWriteQueueFull is returned when the outgoing queue is full and the policy is QueueError
+var ErrWriteQueueFull = errors.New("the outgoing message queue is full")
+
+// ErrMessageDropped is returned for a queued message discarded to make room for a newer one with the QueueDropOldest policy
+var ErrMessageDropped = errors.New("the message was dropped from the outgoing queue to make room for a newer one")
+
+type outgoingFrame struct {
+	msgType int
+	data    []byte
+
+	// result receives the outcome of the write. It's nil for the frames nobody waits for, like the keep alive echo;
+	// errors writing those are delivered through Socket.writeErrors instead.
+	result chan error
+}
+
+// writeLoop is the only goroutine writing data frames to the connection,
+// gorilla/websocket supports only one concurrent writer.
+// Keep alive echoes have their own channel and are always written before any queued message.
+func (s *Socket) writeLoop() {
+	for {
+		var frame *outgoingFrame
+
+		select {
+		case frame = <-s.priorityOutgoing:
+		default:
+			select {
+			case <-s.ctx.Done():
+				return
+			case frame = <-s.priorityOutgoing:
+			case frame = <-s.outgoing:
+			}
+		}
+
+		err := s.writeFrame(frame)
+		if frame.result != nil {
+			frame.result <- err
+			continue
+		}
+		if err != nil {
+			select {
+			case s.writeErrors <- err:
+			default:
+			}
+			// Unblocks the read in connectionLoop, which will pick up the error
+			s.getTransport().Close()
+		}
+	}
+}
+
+func (s *Socket) writeFrame(frame *outgoingFrame) error {
+	transport := s.getTransport()
+	if t, ok := transport.(DeadlineTransport); ok && s.writeTimeout > 0 {
+		t.SetWriteDeadline(time.Now().Add(s.writeTimeout))
+	}
+
+	return transport.WriteFrame(frame.msgType, frame.data)
+}
+
+// writeMessage queues the frame following the queue policy and waits until it's written
+func (s *Socket) writeMessage(msgType int, data []byte) (err error) {
+	frame := &outgoingFrame{msgType: msgType, data: data, result: make(chan error, 1)}
+
+	err = s.enqueue(frame)
+	if err != nil {
+		return
+	}
+
+	select {
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	case err = <-frame.result:
+		return
+	}
+}
+
+func (s *Socket) enqueue(frame *outgoingFrame) error {
+	switch s.queuePolicy {
+	case QueueError:
+		select {
+		case s.outgoing <- frame:
+			return nil
+		default:
+			return ErrWriteQueueFull
+		}
+	case QueueDropOldest:
+		for {
+			select {
+			case <-s.ctx.Done():
+				return s.ctx.Err()
+			case s.outgoing <- frame:
+				return nil
+			default:
+			}
+
+			select {
+			case dropped := <-s.outgoing:
+				if dropped.result != nil {
+					dropped.result <- ErrMessageDropped
+				}
+			default:
+			}
+		}
+	default:
+		select {
+		case <-s.ctx.Done():
+			return s.ctx.Err()
+		case s.outgoing <- frame:
+			return nil
+		}
+	}
+}
+
+// queueKeepAliveMessage queues the frame in the priority channel without waiting for it to be written
+func (s *Socket) queueKeepAliveMessage(msgType int, data []byte) {
+	select {
+	case <-s.ctx.Done():
+	case s.priorityOutgoing <- &outgoingFrame{msgType: msgType, data: data}:
+	}
+}